@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/peeomid/update-tracker/internal/app"
 	"github.com/peeomid/update-tracker/internal/config"
 	"github.com/peeomid/update-tracker/internal/output"
 	"github.com/peeomid/update-tracker/internal/state"
+	"github.com/peeomid/update-tracker/internal/version"
 )
 
 func runCheck(args []string) int {
@@ -20,7 +22,9 @@ func runCheck(args []string) int {
 	configPath := fs.String("config", "", "config path (default: ~/.config/update-tracker/config.yaml)")
 	format := fs.String("format", "text", "output format: text|json|markdown")
 	notes := fs.Bool("notes", true, "include release highlights (only on update); set --notes=false to disable")
-	onlyUpdates := fs.Bool("only-updates", true, "print only updates/errors (default: true); set --only-updates=false to print all")
+	osvCheck := fs.Bool("osv", false, "look up known vulnerabilities of local versions on osv.dev (sends package names and installed versions to api.osv.dev)")
+	minSeverity := fs.String("min-severity", "", "only report updates of at least this kind: major|minor|patch|prerelease")
+	onlyUpdates := fs.Bool("only-updates", true, "print only updates/vulnerable/EOL warnings/errors (default: true); set --only-updates=false to print all")
 	if err := fs.Parse(args); err != nil {
 		if helpRequested(err) {
 			return 0
@@ -31,6 +35,11 @@ func runCheck(args []string) int {
 		return 2
 	}
 
+	if strings.TrimSpace(*minSeverity) != "" && !version.ValidKind(*minSeverity) {
+		fmt.Fprintln(os.Stderr, "invalid --min-severity (use: major|minor|patch|prerelease)")
+		return 2
+	}
+
 	cfg, err := config.Load(config.ResolvePath(*configPath))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
@@ -50,6 +59,8 @@ func runCheck(args []string) int {
 
 	report, newState := app.Run(rootContext(), cfg, st, app.Options{
 		IncludeNotes: *notes,
+		MinSeverity:  strings.TrimSpace(*minSeverity),
+		OSV:          *osvCheck,
 	})
 
 	outReport := report
@@ -57,18 +68,9 @@ func runCheck(args []string) int {
 		outReport.Items = nil
 		outReport.Summary = app.Summary{}
 		for _, it := range report.Items {
-			if it.Status == "update" || it.Status == "error" {
+			if it.Status != "ok" {
 				outReport.Items = append(outReport.Items, it)
-			}
-		}
-		for _, it := range outReport.Items {
-			switch it.Status {
-			case "ok":
-				outReport.Summary.OK++
-			case "update":
-				outReport.Summary.Update++
-			case "error":
-				outReport.Summary.Error++
+				outReport.Summary.Add(it.Status)
 			}
 		}
 	}
@@ -40,7 +40,7 @@ func main() {
 func usageRoot(w *os.File) {
 	fmt.Fprintln(w, "upd - update tracker")
 	fmt.Fprintln(w, "")
-	fmt.Fprintln(w, "Checks for updates (GitHub release/commit/pr, brew, npm) and can compare with local installs/clones.")
+	fmt.Fprintln(w, "Checks for updates (GitHub release/commit/pr, GitLab release/commit/mr, Gitea/Forgejo, any git remote, JSON endpoints, RSS/Atom feeds, web pages, brew formulae/casks, npm, PyPI, crates.io, RubyGems, Packagist, NuGet, Maven, Go modules, OCI images, Helm charts, Terraform providers/modules, endoflife.date) and can compare with local installs/clones.")
 	fmt.Fprintln(w, "")
 	fmt.Fprintln(w, "Default files:")
 	fmt.Fprintf(w, "  config: %s\n", config.DefaultConfigPath())
@@ -105,7 +105,11 @@ func usageCheck(w *os.File) {
 	fmt.Fprintln(w, "  --format FORMAT   text|json|markdown (default: text)")
 	fmt.Fprintln(w, "  --notes BOOL      GitHub release highlights (default: true)")
 	fmt.Fprintln(w, "                   Only included when status=update.")
-	fmt.Fprintln(w, "  --only-updates BOOL  Print only updates/errors (default: true)")
+	fmt.Fprintln(w, "  --only-updates BOOL  Print only updates/vulnerable/EOL warnings/errors (default: true)")
+	fmt.Fprintln(w, "  --min-severity KIND  Only report updates of at least major|minor|patch|prerelease")
+	fmt.Fprintln(w, "                   Per-tracker notifyOn overrides it. Commit/PR changes are always reported.")
+	fmt.Fprintln(w, "  --osv             Look up known vulnerabilities of local versions on osv.dev (default: off)")
+	fmt.Fprintln(w, "                   Sends package names and installed versions to api.osv.dev.")
 	fmt.Fprintln(w, "")
 	fmt.Fprintln(w, "Default paths:")
 	fmt.Fprintf(w, "  config: %s\n", config.DefaultConfigPath())
@@ -116,6 +120,7 @@ func usageCheck(w *os.File) {
 	fmt.Fprintln(w, "  upd check --format json")
 	fmt.Fprintln(w, "  upd check --format markdown --notes=false")
 	fmt.Fprintln(w, "  upd check --format markdown --only-updates=false")
+	fmt.Fprintln(w, "  upd check --min-severity=minor")
 	fmt.Fprintln(w, "  upd check --config ./my-config.yaml --format text")
 }
 
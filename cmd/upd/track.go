@@ -42,12 +42,18 @@ func usageTrack(w *os.File) {
 	fmt.Fprintln(w, "")
 	fmt.Fprintln(w, "Commands:")
 	fmt.Fprintln(w, "  upd track ls [--config PATH]")
-	fmt.Fprintln(w, "  upd track add --url URL [--config PATH] [--name NAME] [--label LABEL] [--group GROUP] [--display DISPLAY]")
+	fmt.Fprintln(w, "  upd track add --url URL [--config PATH] [--type github|gitlab|gitea] [--mode MODE] [--branch BRANCH] [--name NAME] [--label LABEL] [--group GROUP] [--display DISPLAY]")
 	fmt.Fprintln(w, "  upd track rm NAME [--config PATH]")
 	fmt.Fprintln(w, "")
 	fmt.Fprintln(w, "URL examples:")
 	fmt.Fprintln(w, "  https://github.com/OWNER/REPO")
 	fmt.Fprintln(w, "  https://github.com/OWNER/REPO/pull/123")
+	fmt.Fprintln(w, "  https://gitlab.com/GROUP/PROJECT")
+	fmt.Fprintln(w, "  https://gitlab.example.com/GROUP/SUBGROUP/PROJECT/-/merge_requests/45")
+	fmt.Fprintln(w, "  https://codeberg.org/OWNER/REPO/pulls/7")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Self-hosted GitLab/Gitea/Forgejo hosts are detected when the host name")
+	fmt.Fprintln(w, "or path says so; otherwise pass --type.")
 	fmt.Fprintln(w, "")
 	fmt.Fprintln(w, "Tip: validate after changes:")
 	fmt.Fprintln(w, "  upd validate-config")
@@ -79,11 +85,14 @@ func runTrackLS(args []string) int {
 
 	for _, t := range cfg.Trackers {
 		desc := t.Type
-		if t.Type == "github" && strings.TrimSpace(t.Mode) != "" {
+		if strings.TrimSpace(t.Mode) != "" {
 			desc = desc + ":" + t.Mode
 		}
-		if t.Type == "github" && t.Mode == "pr" {
+		switch t.Mode {
+		case "pr":
 			desc = desc + " #" + strconv.Itoa(t.PR)
+		case "mr":
+			desc = desc + " !" + strconv.Itoa(t.PR)
 		}
 		fmt.Printf("%s\t%s\n", t.Name, desc)
 	}
@@ -95,13 +104,14 @@ func runTrackAdd(args []string) int {
 	fs.SetOutput(io.Discard)
 	fs.Usage = func() { usageTrack(os.Stdout) }
 	configPath := fs.String("config", "", "config path (default: ~/.config/update-tracker/config.yaml)")
-	rawURL := fs.String("url", "", "github url (repo or pull request)")
+	rawURL := fs.String("url", "", "github/gitlab/gitea url (repo, pull request or merge request)")
+	typ := fs.String("type", "", "forge type for self-hosted urls: github|gitlab|gitea (optional; detected from the url)")
 	name := fs.String("name", "", "tracker name (optional)")
 	label := fs.String("label", "", "output label (optional)")
 	group := fs.String("group", "", "output group (optional)")
 	display := fs.String("display", "", "display mode (optional)")
-	mode := fs.String("mode", "", "github mode for repo url: release|commit (optional; default: release)")
-	branch := fs.String("branch", "", "github branch (only for mode=commit; optional)")
+	mode := fs.String("mode", "", "mode for repo url: release|commit (optional; default: release)")
+	branch := fs.String("branch", "", "branch (only for mode=commit; optional)")
 	if err := fs.Parse(args); err != nil {
 		if helpRequested(err) {
 			return 0
@@ -114,7 +124,7 @@ func runTrackAdd(args []string) int {
 		return 2
 	}
 
-	kind, repo, prNum, err := parseGitHubURL(*rawURL)
+	ref, err := parseRepoURL(*rawURL, *typ)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		return 2
@@ -127,7 +137,7 @@ func runTrackAdd(args []string) int {
 		return 2
 	}
 
-	entry, err := buildTrackerFromURL(kind, repo, prNum, *mode, *branch)
+	entry, err := buildTrackerFromURL(ref, *mode, *branch)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		return 2
@@ -239,47 +249,123 @@ func loadOrNewConfig(path string) (config.Config, error) {
 	return config.Config{}, err
 }
 
-func parseGitHubURL(raw string) (kind string, repo string, pr int, err error) {
+// repoRef is a repository (or pull/merge request) parsed from a forge URL.
+type repoRef struct {
+	Type string // github|gitlab|gitea
+	Kind string // repo|pr
+	// BaseURL is the instance URL for self-hosted forges ("" for the public one).
+	BaseURL string
+	Repo    string
+	PR      int
+}
+
+func parseRepoURL(raw string, typ string) (repoRef, error) {
 	u, err := url.Parse(strings.TrimSpace(raw))
 	if err != nil {
-		return "", "", 0, fmt.Errorf("invalid url: %w", err)
+		return repoRef{}, fmt.Errorf("invalid url: %w", err)
 	}
-	if !strings.EqualFold(u.Host, "github.com") {
-		return "", "", 0, fmt.Errorf("only github.com supported (got host=%s)", u.Host)
+	host := strings.ToLower(u.Host)
+	if host == "" {
+		return repoRef{}, fmt.Errorf("invalid url: missing host")
 	}
-	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
-	if len(parts) < 2 {
-		return "", "", 0, fmt.Errorf("invalid github url path")
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+
+	typ = strings.TrimSpace(typ)
+	if typ == "" {
+		switch {
+		case host == "github.com":
+			typ = "github"
+		case host == "gitlab.com", strings.Contains(host, "gitlab"), strings.Contains(path, "/-/"):
+			typ = "gitlab"
+		case host == "codeberg.org", strings.Contains(host, "gitea"), strings.Contains(host, "forgejo"):
+			typ = "gitea"
+		default:
+			return repoRef{}, fmt.Errorf("unknown forge host %s (pass --type github|gitlab|gitea)", u.Host)
+		}
 	}
-	repo = parts[0] + "/" + parts[1]
-	if len(parts) >= 4 && parts[2] == "pull" {
-		n, err := strconv.Atoi(parts[3])
-		if err != nil || n <= 0 {
-			return "", "", 0, fmt.Errorf("invalid pull request number")
+
+	ref := repoRef{Type: typ, Kind: "repo"}
+	switch typ {
+	case "github":
+		if host != "github.com" {
+			return repoRef{}, fmt.Errorf("only github.com supported for github (got host=%s)", u.Host)
+		}
+		parts := strings.Split(path, "/")
+		if len(parts) < 2 {
+			return repoRef{}, fmt.Errorf("invalid github url path")
+		}
+		ref.Repo = parts[0] + "/" + parts[1]
+		if len(parts) >= 4 && parts[2] == "pull" {
+			n, err := strconv.Atoi(parts[3])
+			if err != nil || n <= 0 {
+				return repoRef{}, fmt.Errorf("invalid pull request number")
+			}
+			ref.Kind, ref.PR = "pr", n
+		}
+	case "gitlab":
+		if host != "gitlab.com" {
+			ref.BaseURL = u.Scheme + "://" + u.Host
 		}
-		return "pr", repo, n, nil
+		// Project paths can be nested (group/subgroup/project); everything
+		// after "/-/" is a page inside the project.
+		project, rest, _ := strings.Cut(path, "/-/")
+		if strings.Count(project, "/") < 1 {
+			return repoRef{}, fmt.Errorf("invalid gitlab url path")
+		}
+		ref.Repo = project
+		parts := strings.Split(rest, "/")
+		if len(parts) >= 2 && parts[0] == "merge_requests" {
+			n, err := strconv.Atoi(parts[1])
+			if err != nil || n <= 0 {
+				return repoRef{}, fmt.Errorf("invalid merge request number")
+			}
+			ref.Kind, ref.PR = "pr", n
+		}
+	case "gitea":
+		if host != "codeberg.org" {
+			ref.BaseURL = u.Scheme + "://" + u.Host
+		}
+		parts := strings.Split(path, "/")
+		if len(parts) < 2 {
+			return repoRef{}, fmt.Errorf("invalid gitea url path")
+		}
+		ref.Repo = parts[0] + "/" + parts[1]
+		if len(parts) >= 4 && parts[2] == "pulls" {
+			n, err := strconv.Atoi(parts[3])
+			if err != nil || n <= 0 {
+				return repoRef{}, fmt.Errorf("invalid pull request number")
+			}
+			ref.Kind, ref.PR = "pr", n
+		}
+	default:
+		return repoRef{}, fmt.Errorf("--type must be github|gitlab|gitea")
 	}
-	return "repo", repo, 0, nil
+	return ref, nil
 }
 
-func buildTrackerFromURL(kind string, repo string, prNum int, mode string, branch string) (config.TrackerEntry, error) {
-	repo = strings.TrimSpace(repo)
+func buildTrackerFromURL(ref repoRef, mode string, branch string) (config.TrackerEntry, error) {
+	repo := strings.TrimSpace(ref.Repo)
 	if repo == "" {
 		return config.TrackerEntry{}, fmt.Errorf("missing repo")
 	}
+	baseName := strings.ReplaceAll(repo, "/", "-")
 
-	switch kind {
+	switch ref.Kind {
 	case "pr":
-		if strings.TrimSpace(mode) != "" && strings.TrimSpace(mode) != "pr" {
-			return config.TrackerEntry{}, fmt.Errorf("--mode is not allowed for pull request url")
+		prMode := "pr"
+		if ref.Type == "gitlab" {
+			prMode = "mr"
+		}
+		if strings.TrimSpace(mode) != "" && strings.TrimSpace(mode) != prMode {
+			return config.TrackerEntry{}, fmt.Errorf("--mode is not allowed for %s url", prMode)
 		}
-		name := strings.ReplaceAll(repo, "/", "-") + "-pr-" + strconv.Itoa(prNum)
 		return config.TrackerEntry{
-			Name: name,
-			Type: "github",
-			Mode: "pr",
-			Repo: repo,
-			PR:   prNum,
+			Name:    baseName + "-" + prMode + "-" + strconv.Itoa(ref.PR),
+			Type:    ref.Type,
+			Mode:    prMode,
+			Repo:    repo,
+			PR:      ref.PR,
+			BaseURL: ref.BaseURL,
 		}, nil
 	case "repo":
 		m := strings.TrimSpace(mode)
@@ -289,12 +375,12 @@ func buildTrackerFromURL(kind string, repo string, prNum int, mode string, branc
 		if m != "release" && m != "commit" {
 			return config.TrackerEntry{}, fmt.Errorf("--mode must be release|commit")
 		}
-		name := strings.ReplaceAll(repo, "/", "-") + "-" + m
 		e := config.TrackerEntry{
-			Name: name,
-			Type: "github",
-			Mode: m,
-			Repo: repo,
+			Name:    baseName + "-" + m,
+			Type:    ref.Type,
+			Mode:    m,
+			Repo:    repo,
+			BaseURL: ref.BaseURL,
 		}
 		if m == "commit" {
 			b := strings.TrimSpace(branch)
@@ -2,11 +2,14 @@ package app
 
 import (
 	"context"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/peeomid/update-tracker/internal/config"
 	"github.com/peeomid/update-tracker/internal/execx"
 	"github.com/peeomid/update-tracker/internal/httpx"
+	"github.com/peeomid/update-tracker/internal/osv"
 	"github.com/peeomid/update-tracker/internal/state"
 	"github.com/peeomid/update-tracker/internal/trackers"
 )
@@ -23,30 +26,62 @@ type Report struct {
 type Summary struct {
 	OK     int `json:"ok"`
 	Update int `json:"update"`
-	Error  int `json:"error"`
+	// EOL counts eol and eol-soon items.
+	EOL        int `json:"eol"`
+	Vulnerable int `json:"vulnerable"`
+	Error      int `json:"error"`
+}
+
+// Add counts an item with the given status.
+func (s *Summary) Add(status string) {
+	switch status {
+	case "ok":
+		s.OK++
+	case "update":
+		s.Update++
+	case statusEOL, statusEOLSoon:
+		s.EOL++
+	case statusVulnerable:
+		s.Vulnerable++
+	case "error":
+		s.Error++
+	}
 }
 
 type ReportItem struct {
-	Name       string            `json:"name"`
-	Type       string            `json:"type"`
-	Mode       string            `json:"mode,omitempty"`
-	Label      string            `json:"label,omitempty"`
-	Group      string            `json:"group,omitempty"`
-	Display    string            `json:"display,omitempty"`
-	Status     string            `json:"status"`
-	Prev       string            `json:"prev,omitempty"`
-	Current    string            `json:"current,omitempty"`
-	Latest     string            `json:"latest,omitempty"`
-	Local      string            `json:"local,omitempty"`
-	Message    string            `json:"message"`
-	Links      map[string]string `json:"links,omitempty"`
-	Highlights string            `json:"highlights,omitempty"`
-	Error      string            `json:"error,omitempty"`
-	LocalError string            `json:"localError,omitempty"`
+	Name        string             `json:"name"`
+	Type        string             `json:"type"`
+	Mode        string             `json:"mode,omitempty"`
+	Label       string             `json:"label,omitempty"`
+	Group       string             `json:"group,omitempty"`
+	Display     string             `json:"display,omitempty"`
+	Status      string             `json:"status"`
+	Prev        string             `json:"prev,omitempty"`
+	Current     string             `json:"current,omitempty"`
+	Latest      string             `json:"latest,omitempty"`
+	Local       string             `json:"local,omitempty"`
+	Compare     string             `json:"compare,omitempty"`     // local vs latest: behind|equal|ahead
+	UpdateKind  string             `json:"updateKind,omitempty"`  // major|minor|patch|prerelease
+	BehindCount int                `json:"behindCount,omitempty"` // published versions between local and latest
+	Message     string             `json:"message"`
+	Links       map[string]string  `json:"links,omitempty"`
+	Highlights  string             `json:"highlights,omitempty"`
+	Skipped     []trackers.Release `json:"skipped,omitempty"`    // releases since prev, newest first
+	Cycle       string             `json:"cycle,omitempty"`      // eol: release cycle of the local version
+	EOL         string             `json:"eol,omitempty"`        // eol: end-of-life date of that cycle
+	Advisories  []osv.Advisory     `json:"advisories,omitempty"` // OSV advisories affecting the local version
+	Error       string             `json:"error,omitempty"`
+	LocalError  string             `json:"localError,omitempty"`
+	OSVError    string             `json:"osvError,omitempty"`
 }
 
 type Options struct {
 	IncludeNotes bool
+	// MinSeverity hides updates below this kind (major|minor|patch|prerelease).
+	// A tracker's notifyOn overrides it.
+	MinSeverity string
+	// OSV looks up advisories for local versions on osv.dev.
+	OSV bool
 }
 
 func Run(ctx context.Context, cfg config.Config, st state.State, opts Options) (Report, state.State) {
@@ -58,13 +93,15 @@ func Run(ctx context.Context, cfg config.Config, st state.State, opts Options) (
 	execRunner := execx.NewCachedRunner(execx.OSRunner{})
 
 	registry := trackers.Registry{
-		HTTP:      httpClient,
-		Exec:      execRunner,
-		UserAgent: cfg.Defaults.UserAgent,
+		HTTP:        httpClient,
+		Exec:        execRunner,
+		UserAgent:   cfg.Defaults.UserAgent,
+		GitHubToken: githubToken(),
 	}
 
 	run := runner{
 		Registry:    registry,
+		OSV:         osv.Client{HTTP: httpClient, UserAgent: cfg.Defaults.UserAgent},
 		Timeout:     timeout,
 		Retries:     cfg.Defaults.Retries,
 		Concurrency: cfg.Defaults.Concurrency,
@@ -76,14 +113,7 @@ func Run(ctx context.Context, cfg config.Config, st state.State, opts Options) (
 
 	var summary Summary
 	for _, it := range items {
-		switch it.Status {
-		case "ok":
-			summary.OK++
-		case "update":
-			summary.Update++
-		case "error":
-			summary.Error++
-		}
+		summary.Add(it.Status)
 	}
 
 	return Report{
@@ -94,3 +124,12 @@ func Run(ctx context.Context, cfg config.Config, st state.State, opts Options) (
 		Duration:      time.Since(start),
 	}, nextState
 }
+
+func githubToken() string {
+	for _, k := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if v := strings.TrimSpace(os.Getenv(k)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
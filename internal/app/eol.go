@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/peeomid/update-tracker/internal/config"
+	"github.com/peeomid/update-tracker/internal/trackers"
+)
+
+const (
+	statusEOL     = "eol"
+	statusEOLSoon = "eol-soon"
+
+	defaultEOLWarnDays = 90
+)
+
+// matchCycle finds the release cycle a local version belongs to: the longest
+// cycle name that equals the version or prefixes it at a dot (18 for
+// 18.20.4, 3.12 for 3.12.1, 22.04 for 22.04.4).
+func matchCycle(cycles []trackers.Cycle, local string) (trackers.Cycle, bool) {
+	local = strings.TrimPrefix(strings.TrimSpace(local), "v")
+	var best trackers.Cycle
+	found := false
+	for _, c := range cycles {
+		if local != c.Name && !strings.HasPrefix(local, c.Name+".") {
+			continue
+		}
+		if !found || len(c.Name) > len(best.Name) {
+			best, found = c, true
+		}
+	}
+	return best, found
+}
+
+// cycleMessage is the eol tracker's message for the installed cycle rather
+// than the newest one.
+func cycleMessage(c trackers.Cycle, prevSeen string) string {
+	if prevSeen != "" && prevSeen != c.Latest {
+		return fmt.Sprintf("new version %s (cycle %s)", c.Latest, c.Name)
+	}
+	return fmt.Sprintf("latest %s (cycle %s)", c.Latest, c.Name)
+}
+
+// eolStatus reports eol once the cycle's end of life is reached, eol-soon
+// within the tracker's warning window, else "". note describes the EOL date
+// either way, e.g. "Node 18 reached EOL 30 days ago (2025-04-30)".
+func eolStatus(cfg config.TrackerEntry, c trackers.Cycle, now time.Time) (status string, note string) {
+	name := strings.TrimSpace(cfg.Label)
+	if name == "" {
+		name = cfg.Product
+	}
+	name = fmt.Sprintf("%s %s", name, c.Name)
+
+	eol, err := time.Parse("2006-01-02", c.EOL)
+	if err != nil {
+		if c.Ended {
+			return statusEOL, name + " reached EOL"
+		}
+		return "", name + " has no EOL date yet"
+	}
+	warn := defaultEOLWarnDays
+	if cfg.EOLWarnDays != nil {
+		warn = *cfg.EOLWarnDays
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(eol.Sub(today).Hours() / 24)
+	switch {
+	case days < 0:
+		return statusEOL, fmt.Sprintf("%s reached EOL %s ago (%s)", name, pluralDays(-days), c.EOL)
+	case days == 0:
+		return statusEOL, fmt.Sprintf("%s reaches EOL today (%s)", name, c.EOL)
+	case days <= warn:
+		return statusEOLSoon, fmt.Sprintf("%s reaches EOL in %s (%s)", name, pluralDays(days), c.EOL)
+	default:
+		return "", fmt.Sprintf("%s EOL %s", name, c.EOL)
+	}
+}
+
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
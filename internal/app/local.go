@@ -0,0 +1,487 @@
+package app
+
+import (
+	"context"
+	"debug/buildinfo"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/config"
+	"github.com/peeomid/update-tracker/internal/trackers"
+)
+
+func runLocalCheck(ctx context.Context, r runner, cfg config.TrackerEntry) (string, string) {
+	switch strings.TrimSpace(cfg.Local.Type) {
+	case "":
+		return "", ""
+	case "command":
+		// Use zsh -lc so the user's shell init is loaded (PATH, nvm, etc).
+		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		out, err := r.Registry.Exec.Run(attemptCtx, "zsh", "-lc", cfg.Local.Command)
+		cancel()
+		if err != nil {
+			return "", err.Error()
+		}
+		out = strings.TrimSpace(out)
+		if out == "" {
+			return "unknown", ""
+		}
+		if strings.TrimSpace(cfg.Local.Regex) == "" {
+			if m := versionRe.FindString(out); m != "" {
+				return m, ""
+			}
+			return "unknown", ""
+		}
+		re, err := regexp.Compile(cfg.Local.Regex)
+		if err != nil {
+			return "", "invalid local.regex: " + err.Error()
+		}
+		if m := re.FindString(out); m != "" {
+			return m, ""
+		}
+		return "unknown", ""
+	case "git":
+		args := []string{"-C", cfg.Local.Path, "rev-parse", "HEAD"}
+		if cfg.Mode == "tag" {
+			// Tag trackers compare versions: use the tag the clone is on (or after).
+			args = []string{"-C", cfg.Local.Path, "describe", "--tags", "--abbrev=0"}
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		out, err := r.Registry.Exec.Run(attemptCtx, "git", args...)
+		cancel()
+		if err != nil {
+			return "", err.Error()
+		}
+		out = strings.TrimSpace(out)
+		if out == "" {
+			return "unknown", ""
+		}
+		if cfg.Mode == "tag" {
+			return trackers.TagVersion(cfg, out), ""
+		}
+		return out, ""
+	case "npm":
+		pkg := cfg.Package
+		if strings.TrimSpace(cfg.Local.Package) != "" {
+			pkg = cfg.Local.Package
+		}
+		if strings.TrimSpace(pkg) == "" {
+			return "", "missing npm package"
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		out, err := r.Registry.Exec.Run(attemptCtx, "npm", "list", pkg, "--depth=0", "-g", "--json")
+		cancel()
+
+		// npm returns non-zero for missing packages, but still prints JSON. Parse stdout first.
+		if v := parseNpmListJSON(out, pkg); v != "" {
+			return v, ""
+		}
+		if err != nil {
+			msg := strings.ToLower(err.Error())
+			if strings.Contains(msg, "missing") || strings.Contains(msg, "not installed") || strings.Contains(msg, "empty") {
+				return "not-installed", ""
+			}
+			return "", err.Error()
+		}
+		return "unknown", ""
+	case "pip":
+		pkg := cfg.Package
+		if strings.TrimSpace(cfg.Local.Package) != "" {
+			pkg = cfg.Local.Package
+		}
+		if strings.TrimSpace(pkg) == "" {
+			return "", "missing pip package"
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		out, err := r.Registry.Exec.Run(attemptCtx, "python3", "-m", "pip", "list", "--format=json")
+		cancel()
+		if err != nil {
+			return "", err.Error()
+		}
+		v, ok, err := parsePipListJSON(out, pkg)
+		if err != nil {
+			return "", err.Error()
+		}
+		if ok {
+			return v, ""
+		}
+		return "not-installed", ""
+	case "cargo":
+		pkg := cfg.Package
+		if strings.TrimSpace(cfg.Local.Package) != "" {
+			pkg = cfg.Local.Package
+		}
+		if strings.TrimSpace(pkg) == "" {
+			return "", "missing cargo package"
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		out, err := r.Registry.Exec.Run(attemptCtx, "cargo", "install", "--list")
+		cancel()
+		if err != nil {
+			return "", err.Error()
+		}
+		if v, ok := parseCargoInstallList(out, pkg); ok {
+			return v, ""
+		}
+		return "not-installed", ""
+	case "brew":
+		// Same command as the tracker, so the cached output is reused.
+		args := []string{"info", "--json=v2"}
+		if cfg.Cask {
+			args = append(args, "--cask")
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		out, err := r.Registry.Exec.Run(attemptCtx, "brew", append(args, cfg.Formula)...)
+		cancel()
+		if err != nil {
+			return "", err.Error()
+		}
+		if v, ok := parseBrewInstalled(out); ok {
+			return v, ""
+		}
+		return "not-installed", ""
+	case "helm":
+		release := strings.TrimSpace(cfg.Local.Release)
+		if release == "" {
+			release = cfg.Chart
+		}
+		args := []string{"list", "-o", "json", "--filter", "^" + regexp.QuoteMeta(release) + "$"}
+		if ns := strings.TrimSpace(cfg.Local.Namespace); ns != "" {
+			args = append(args, "-n", ns)
+		} else {
+			args = append(args, "-A")
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		out, err := r.Registry.Exec.Run(attemptCtx, "helm", args...)
+		cancel()
+		if err != nil {
+			return "", err.Error()
+		}
+		if v, ok := parseHelmList(out, release, cfg.Chart); ok {
+			return v, ""
+		}
+		return "not-installed", ""
+	case "terraform":
+		lock := filepath.Join(expandHome(cfg.Local.Path), ".terraform.lock.hcl")
+		b, err := os.ReadFile(lock)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return "not-installed", ""
+			}
+			return "", err.Error()
+		}
+		if v, ok := parseTerraformLock(string(b), cfg.Source); ok {
+			return v, ""
+		}
+		return "not-installed", ""
+	case "dpkg":
+		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		out, err := r.Registry.Exec.Run(attemptCtx, "dpkg-query", "-W", "-f", "${Version}\n", cfg.Local.Package)
+		cancel()
+		if err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), "no packages found") {
+				return "not-installed", ""
+			}
+			return "", err.Error()
+		}
+		// Removed packages that kept their config files have no version.
+		if v := firstLine(out); v != "" {
+			return v, ""
+		}
+		return "not-installed", ""
+	case "rpm":
+		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		out, err := r.Registry.Exec.Run(attemptCtx, "rpm", "-q", "--qf", "%{EPOCH}:%{VERSION}-%{RELEASE}\n", cfg.Local.Package)
+		cancel()
+		if err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), "is not installed") {
+				return "not-installed", ""
+			}
+			return "", err.Error()
+		}
+		return parseRpmVersion(out), ""
+	case "gobinary":
+		return readGoBinaryVersion(expandHome(cfg.Local.Path), cfg.Module)
+	case "docker":
+		image, tag := cfg.ImageRef()
+		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		out, err := r.Registry.Exec.Run(attemptCtx, "docker", "image", "inspect", "--format", "{{json .RepoDigests}}", image+":"+tag)
+		cancel()
+		if err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), "no such image") {
+				return "not-installed", ""
+			}
+			return "", err.Error()
+		}
+		if d := parseRepoDigests(out, image); d != "" {
+			return d, ""
+		}
+		return "unknown", ""
+	default:
+		return "", "unknown local.type: " + cfg.Local.Type
+	}
+}
+
+// parseHelmList finds release in `helm list -o json` output and returns its
+// chart version (the "chart" field is "<chart>-<version>").
+func parseHelmList(out string, release string, chart string) (string, bool) {
+	var list []struct {
+		Name  string `json:"name"`
+		Chart string `json:"chart"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &list); err != nil {
+		return "", false
+	}
+	for _, rel := range list {
+		if rel.Name != release {
+			continue
+		}
+		if v, ok := strings.CutPrefix(rel.Chart, chart+"-"); ok {
+			return v, true
+		}
+		// Installed from a differently named chart: take what looks like a version.
+		if m := versionRe.FindString(rel.Chart); m != "" {
+			return m, true
+		}
+		return "unknown", true
+	}
+	return "", false
+}
+
+// parseTerraformLock returns the version locked for a provider in a
+// .terraform.lock.hcl file. Lock addresses carry the registry host
+// (registry.terraform.io/hashicorp/aws); source may omit it.
+func parseTerraformLock(lock string, source string) (string, bool) {
+	source = strings.ToLower(strings.Trim(strings.TrimSpace(source), "/"))
+	inBlock := false
+	for _, line := range strings.Split(lock, "\n") {
+		line = strings.TrimSpace(line)
+		if m := tfProviderRe.FindStringSubmatch(line); m != nil {
+			addr := strings.ToLower(m[1])
+			inBlock = addr == source || strings.HasSuffix(addr, "/"+source)
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+		if m := tfVersionRe.FindStringSubmatch(line); m != nil {
+			return m[1], true
+		}
+		if line == "}" {
+			inBlock = false
+		}
+	}
+	return "", false
+}
+
+var (
+	tfProviderRe = regexp.MustCompile(`^provider\s+"([^"]+)"\s*\{`)
+	tfVersionRe  = regexp.MustCompile(`^version\s*=\s*"([^"]+)"`)
+)
+
+// firstLine returns the first non-empty line of out. dpkg-query and rpm print
+// one line per installed instance (multi-arch packages, kernels).
+func firstLine(out string) string {
+	for _, line := range strings.Split(out, "\n") {
+		if v := strings.TrimSpace(line); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseRpmVersion returns the first version in `rpm -q --qf
+// '%{EPOCH}:%{VERSION}-%{RELEASE}\n'` output, dropping the unset "(none)" epoch.
+func parseRpmVersion(out string) string {
+	v := firstLine(out)
+	if v == "" {
+		return "unknown"
+	}
+	return strings.TrimPrefix(v, "(none):")
+}
+
+func parseNpmListJSON(out string, pkg string) string {
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return ""
+	}
+	type npmDep struct {
+		Version string `json:"version"`
+	}
+	type npmList struct {
+		Dependencies map[string]npmDep `json:"dependencies"`
+	}
+
+	var parsed npmList
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		return ""
+	}
+	if parsed.Dependencies == nil {
+		return ""
+	}
+	dep, ok := parsed.Dependencies[pkg]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(dep.Version)
+}
+
+// parsePipListJSON finds pkg in `pip list --format=json` output. ok is false
+// when the package is not installed; err is set when the output isn't JSON.
+func parsePipListJSON(out string, pkg string) (string, bool, error) {
+	var list []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &list); err != nil {
+		return "", false, fmt.Errorf("pip list json: %w", err)
+	}
+	want := normalizePipName(pkg)
+	for _, p := range list {
+		if normalizePipName(p.Name) == want {
+			return strings.TrimSpace(p.Version), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+var pipNameSepRe = regexp.MustCompile(`[-_.]+`)
+
+// normalizePipName applies PEP 503 name normalization (Foo_Bar == foo-bar).
+func normalizePipName(name string) string {
+	return pipNameSepRe.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
+
+var brewRevisionRe = regexp.MustCompile(`_\d+$`)
+
+// parseBrewInstalled reads the installed version from `brew info --json=v2`
+// output: the linked keg (else the last installed one) for formulae, the
+// installed version for casks. The formula revision (6.0_1) is dropped, as
+// the stable version the tracker reports has none.
+func parseBrewInstalled(out string) (string, bool) {
+	var info struct {
+		Formulae []struct {
+			LinkedKeg string `json:"linked_keg"`
+			Installed []struct {
+				Version string `json:"version"`
+			} `json:"installed"`
+		} `json:"formulae"`
+		Casks []struct {
+			Installed string `json:"installed"`
+		} `json:"casks"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &info); err != nil {
+		return "", false
+	}
+	if len(info.Formulae) > 0 {
+		f := info.Formulae[0]
+		v := strings.TrimSpace(f.LinkedKeg)
+		if n := len(f.Installed); v == "" && n > 0 {
+			v = strings.TrimSpace(f.Installed[n-1].Version)
+		}
+		v = brewRevisionRe.ReplaceAllString(v, "")
+		return v, v != ""
+	}
+	if len(info.Casks) > 0 {
+		// Cask versions may carry a build suffix (7.1,1234).
+		v, _, _ := strings.Cut(strings.TrimSpace(info.Casks[0].Installed), ",")
+		return v, v != ""
+	}
+	return "", false
+}
+
+// parseCargoInstallList finds pkg in `cargo install --list` output, where each
+// crate is a line like "ripgrep v14.1.0:" (or "foo v0.1.0 (/src/foo):")
+// followed by indented binary names.
+func parseCargoInstallList(out string, pkg string) (string, bool) {
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(line), ":"))
+		if len(fields) < 2 || fields[0] != pkg {
+			continue
+		}
+		return strings.TrimPrefix(strings.TrimSuffix(fields[1], ":"), "v"), true
+	}
+	return "", false
+}
+
+// readGoBinaryVersion reads the module version embedded in a Go binary (what
+// `go version -m` prints). If module is not the binary's main module (e.g. a
+// tool under cmd/ of a bigger module) the matching dependency is used.
+func readGoBinaryVersion(path string, module string) (string, string) {
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "not-installed", ""
+		}
+		return "", err.Error()
+	}
+	if v := goModuleVersion(info, module); v != "" {
+		return v, ""
+	}
+	return "unknown", ""
+}
+
+// goModuleVersion returns the version of module in info, or "" when the
+// binary doesn't embed it or was built from a local checkout.
+func goModuleVersion(info *debug.BuildInfo, module string) string {
+	v := ""
+	if module == "" || info.Main.Path == module {
+		v = info.Main.Version
+	} else {
+		for _, dep := range info.Deps {
+			if dep.Path == module {
+				v = dep.Version
+				break
+			}
+		}
+	}
+	if v == "(devel)" {
+		return ""
+	}
+	return v
+}
+
+func expandHome(p string) string {
+	p = strings.TrimSpace(p)
+	if p != "~" && !strings.HasPrefix(p, "~/") {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+	return filepath.Join(home, strings.TrimPrefix(p, "~"))
+}
+
+// parseRepoDigests picks the digest for image from `docker image inspect
+// --format '{{json .RepoDigests}}'` output (["postgres@sha256:..."]).
+func parseRepoDigests(out string, image string) string {
+	var digests []string
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &digests); err != nil {
+		return ""
+	}
+	first := ""
+	for _, d := range digests {
+		repo, digest, ok := strings.Cut(d, "@")
+		if !ok {
+			continue
+		}
+		if repo == image {
+			return digest
+		}
+		if first == "" {
+			first = digest
+		}
+	}
+	return first
+}
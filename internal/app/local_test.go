@@ -0,0 +1,154 @@
+package app
+
+import (
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+)
+
+func TestParsePipListJSON(t *testing.T) {
+	out := `[{"name": "Jinja2", "version": "3.1.4"}, {"name": "typing_extensions", "version": "4.12.2"}]`
+	if v, ok, err := parsePipListJSON(out, "jinja2"); err != nil || !ok || v != "3.1.4" {
+		t.Fatalf("jinja2: %q %t %v", v, ok, err)
+	}
+	if v, ok, err := parsePipListJSON(out, "typing-extensions"); err != nil || !ok || v != "4.12.2" {
+		t.Fatalf("typing-extensions: %q %t %v", v, ok, err)
+	}
+	if _, ok, err := parsePipListJSON(out, "httpie"); err != nil || ok {
+		t.Fatalf("httpie should not be installed: %v", err)
+	}
+	if _, _, err := parsePipListJSON("WARNING: pip is being invoked by an old script wrapper", "httpie"); err == nil {
+		t.Fatalf("expected an error for non-JSON output")
+	}
+}
+
+func TestParseCargoInstallList(t *testing.T) {
+	out := "bat v0.24.0:\n    bat\ncargo-nextest v0.9.67:\n    cargo-nextest\nmytool v0.1.0 (/home/me/src/mytool):\n    mytool\n"
+	if v, ok := parseCargoInstallList(out, "cargo-nextest"); !ok || v != "0.9.67" {
+		t.Fatalf("cargo-nextest: %q %t", v, ok)
+	}
+	if v, ok := parseCargoInstallList(out, "mytool"); !ok || v != "0.1.0" {
+		t.Fatalf("mytool: %q %t", v, ok)
+	}
+	if _, ok := parseCargoInstallList(out, "ripgrep"); ok {
+		t.Fatalf("ripgrep should not be installed")
+	}
+}
+
+func TestReadGoBinaryVersion(t *testing.T) {
+	info := &debug.BuildInfo{
+		Main: debug.Module{Path: "golang.org/x/tools/gopls", Version: "v0.16.1"},
+		Deps: []*debug.Module{{Path: "golang.org/x/tools", Version: "v0.22.0"}},
+	}
+	cases := []struct {
+		module string
+		want   string
+	}{
+		{"", "v0.16.1"},
+		{"golang.org/x/tools/gopls", "v0.16.1"},
+		{"golang.org/x/tools", "v0.22.0"},
+		{"github.com/other/tool", ""},
+	}
+	for _, tc := range cases {
+		if got := goModuleVersion(info, tc.module); got != tc.want {
+			t.Fatalf("module %q: got %q want %q", tc.module, got, tc.want)
+		}
+	}
+	info.Main.Version = "(devel)"
+	if got := goModuleVersion(info, ""); got != "" {
+		t.Fatalf("devel build: got %q", got)
+	}
+	if v, _ := readGoBinaryVersion(filepath.Join(t.TempDir(), "missing"), ""); v != "not-installed" {
+		t.Fatalf("missing binary: %q", v)
+	}
+}
+
+func TestParseRepoDigests(t *testing.T) {
+	out := `["ghcr.io/org/postgres@sha256:aaa","postgres@sha256:bbb"]`
+	if got := parseRepoDigests(out, "postgres"); got != "sha256:bbb" {
+		t.Fatalf("got %q", got)
+	}
+	if got := parseRepoDigests(out, "other"); got != "sha256:aaa" {
+		t.Fatalf("fallback: got %q", got)
+	}
+	if got := parseRepoDigests("[]", "postgres"); got != "" {
+		t.Fatalf("empty: got %q", got)
+	}
+}
+
+func TestParseBrewInstalled(t *testing.T) {
+	formula := `{"formulae": [{"name": "ffmpeg", "linked_keg": "6.0_1", "installed": [{"version": "5.1.2"}, {"version": "6.0_1"}]}], "casks": []}`
+	if v, ok := parseBrewInstalled(formula); !ok || v != "6.0" {
+		t.Fatalf("formula: %q %t", v, ok)
+	}
+	cask := `{"formulae": [], "casks": [{"token": "iterm2", "version": "3.5.4", "installed": "3.5.3,20240801"}]}`
+	if v, ok := parseBrewInstalled(cask); !ok || v != "3.5.3" {
+		t.Fatalf("cask: %q %t", v, ok)
+	}
+	if _, ok := parseBrewInstalled(`{"formulae": [{"name": "jq", "installed": []}]}`); ok {
+		t.Fatalf("jq should not be installed")
+	}
+}
+
+func TestParseRpmVersion(t *testing.T) {
+	if got := parseRpmVersion("(none):2.43.5-1.el9\n"); got != "2.43.5-1.el9" {
+		t.Fatalf("got %q", got)
+	}
+	if got := parseRpmVersion("2:8.2.2637-20.el9\n"); got != "2:8.2.2637-20.el9" {
+		t.Fatalf("got %q", got)
+	}
+	// Several installed instances print one line each.
+	if got := parseRpmVersion("(none):5.14.0-427.el9\n(none):5.14.0-362.el9\n"); got != "5.14.0-427.el9" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestFirstLine(t *testing.T) {
+	// dpkg-query prints one line per architecture; config-files-only ones are empty.
+	if got := firstLine("\n1:2.39.2-1ubuntu1\n1:2.39.2-1ubuntu1\n"); got != "1:2.39.2-1ubuntu1" {
+		t.Fatalf("got %q", got)
+	}
+	if got := firstLine(""); got != "" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestParseHelmList(t *testing.T) {
+	out := `[{"name":"ingress","namespace":"ingress-nginx","revision":"3","status":"deployed","chart":"ingress-nginx-4.10.0","app_version":"1.10.0"},
+{"name":"certs","namespace":"cert-manager","revision":"1","status":"deployed","chart":"cert-manager-v1.14.4","app_version":"v1.14.4"}]`
+	if v, ok := parseHelmList(out, "ingress", "ingress-nginx"); !ok || v != "4.10.0" {
+		t.Fatalf("ingress: %q %t", v, ok)
+	}
+	if v, ok := parseHelmList(out, "certs", "cert-manager"); !ok || v != "v1.14.4" {
+		t.Fatalf("certs: %q %t", v, ok)
+	}
+	if _, ok := parseHelmList(`[]`, "ingress", "ingress-nginx"); ok {
+		t.Fatalf("empty list should not be installed")
+	}
+}
+
+func TestParseTerraformLock(t *testing.T) {
+	lock := `# This file is maintained automatically by "terraform init".
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.39.1"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:abc=",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.0"
+}
+`
+	if v, ok := parseTerraformLock(lock, "hashicorp/aws"); !ok || v != "5.39.1" {
+		t.Fatalf("aws: %q %t", v, ok)
+	}
+	if v, ok := parseTerraformLock(lock, "registry.terraform.io/hashicorp/random"); !ok || v != "3.6.0" {
+		t.Fatalf("random: %q %t", v, ok)
+	}
+	if _, ok := parseTerraformLock(lock, "hashicorp/google"); ok {
+		t.Fatalf("google should not be locked")
+	}
+}
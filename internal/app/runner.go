@@ -2,7 +2,6 @@ package app
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -12,12 +11,21 @@ import (
 	"time"
 
 	"github.com/peeomid/update-tracker/internal/config"
+	"github.com/peeomid/update-tracker/internal/osv"
 	"github.com/peeomid/update-tracker/internal/state"
 	"github.com/peeomid/update-tracker/internal/trackers"
+	"github.com/peeomid/update-tracker/internal/version"
+)
+
+const (
+	compareBehind = "behind"
+	compareEqual  = "equal"
+	compareAhead  = "ahead"
 )
 
 type runner struct {
 	Registry    trackers.Registry
+	OSV         osv.Client
 	Timeout     time.Duration
 	Retries     int
 	Concurrency int
@@ -98,6 +106,9 @@ func (r runner) runOne(ctx context.Context, cfg config.TrackerEntry, prev state.
 		message    string
 		links      map[string]string
 		highlights string
+		skipped    []trackers.Release
+		versions   []string
+		cycles     []trackers.Cycle
 		localErr   string
 	)
 
@@ -105,8 +116,12 @@ func (r runner) runOne(ctx context.Context, cfg config.TrackerEntry, prev state.
 	for attempt := 0; attempt <= r.Retries; attempt++ {
 		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
 		res, err := tr.Check(attemptCtx, prev.LastSeen, trackers.Options{IncludeNotes: r.Options.IncludeNotes})
-		current, message, links, highlights = res.Current, res.Message, res.Links, res.Highlights
+		current, message, links, highlights, skipped = res.Current, res.Message, res.Links, res.Highlights, res.Skipped
+		versions, cycles = res.Versions, res.Cycles
 		latest = normalizeLatest(cfg, current)
+		if strings.TrimSpace(res.Version) != "" {
+			latest = res.Version
+		}
 		lastErr = err
 		cancel()
 		if lastErr == nil {
@@ -143,12 +158,76 @@ func (r runner) runOne(ctx context.Context, cfg config.TrackerEntry, prev state.
 	local, localErr = runLocalCheck(ctx, r, cfg)
 	local = strings.TrimSpace(local)
 
+	// eol trackers: compare within the installed cycle and check its end of life.
+	var cycle trackers.Cycle
+	eol, eolNote := "", ""
+	newCycle := false
+	if c, ok := matchCycle(cycles, localVersion(cfg, local)); ok {
+		cycle = c
+		if c.Latest != "" {
+			// Follow the installed cycle: a release in a newer cycle is not an
+			// update for this install. The first run on a cycle (or after the
+			// install moved to another one) starts from its latest.
+			_, sameCycle := matchCycle([]trackers.Cycle{c}, prevSeen)
+			newCycle = !sameCycle
+			prev := prevSeen
+			if newCycle {
+				prev = ""
+			}
+			latest, currSeen = c.Latest, c.Latest
+			message = cycleMessage(c, prev)
+		}
+		eol, eolNote = eolStatus(cfg, c, r.RunAt)
+		message = eolNote + "; " + message
+	}
+
+	// Advisories for the installed version (registry trackers, github releases).
+	var advisories []osv.Advisory
+	osvErr := ""
+	if eco, name, ok := osvPackage(cfg); ok && r.Options.OSV {
+		if _, isVersion := version.Parse(localVersion(cfg, local)); isVersion {
+			attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+			found, err := r.OSV.Query(attemptCtx, eco, name, osvVersion(eco, localVersion(cfg, local), currSeen))
+			cancel()
+			if err != nil {
+				osvErr = err.Error()
+			}
+			advisories = found
+		}
+	}
+
 	status := "ok"
-	remoteChanged := prevSeen != "" && currSeen != "" && prevSeen != currSeen
-	localChanged := localUpdateAvailable(cfg, local, latest)
-	if remoteChanged || localChanged {
+	remoteChanged := !newCycle && prevSeen != "" && currSeen != "" && prevSeen != currSeen
+	compare := compareLocal(cfg, local, latest)
+	if remoteChanged || compare == compareBehind {
 		status = "update"
 	}
+
+	updateKind := ""
+	behindCount := 0
+	if compare == compareBehind {
+		updateKind = version.KindStrings(localVersion(cfg, local), latest)
+		behindCount = countBehind(localVersion(cfg, local), latest, versions)
+	} else if remoteChanged {
+		prevVersion := normalizeLatest(cfg, prevSeen)
+		if cfg.Mode == "tag" {
+			// LastSeen is the raw tag (cli/v1.2.0); latest is its version.
+			prevVersion = trackers.TagVersion(cfg, prevSeen)
+		}
+		updateKind = version.KindStrings(prevVersion, latest)
+	}
+	if status == "update" && !r.shouldNotify(cfg, updateKind) {
+		status = "ok"
+	}
+	if eol != "" {
+		// End of life outranks "a new version exists".
+		status = eol
+	}
+	if len(advisories) > 0 {
+		// ...and being exposed outranks both.
+		status = statusVulnerable
+		message = vulnNote(local, advisories) + "; " + message
+	}
 	if strings.TrimSpace(localErr) != "" && strings.TrimSpace(cfg.Local.Type) != "" {
 		// local check failed, but remote might still be ok. Keep the run "ok", but surface localError for output.
 	}
@@ -157,7 +236,6 @@ func (r runner) runOne(ctx context.Context, cfg config.TrackerEntry, prev state.
 	// we still want highlights for the latest release (same style as your old script).
 	if status == "update" &&
 		!remoteChanged &&
-		cfg.Type == "github" &&
 		cfg.Mode == "release" &&
 		strings.TrimSpace(highlights) == "" &&
 		r.Options.IncludeNotes {
@@ -170,21 +248,29 @@ func (r runner) runOne(ctx context.Context, cfg config.TrackerEntry, prev state.
 	}
 
 	res := ReportItem{
-		Name:       cfg.Name,
-		Type:       cfg.Type,
-		Mode:       cfg.Mode,
-		Label:      cfg.Label,
-		Group:      cfg.Group,
-		Display:    cfg.Display,
-		Status:     status,
-		Prev:       prevSeen,
-		Current:    currSeen,
-		Latest:     strings.TrimSpace(latest),
-		Local:      local,
-		Message:    message,
-		Links:      links,
-		Highlights: highlights,
-		LocalError: strings.TrimSpace(localErr),
+		Name:        cfg.Name,
+		Type:        cfg.Type,
+		Mode:        cfg.Mode,
+		Label:       cfg.Label,
+		Group:       cfg.Group,
+		Display:     cfg.Display,
+		Status:      status,
+		Prev:        prevSeen,
+		Current:     currSeen,
+		Latest:      strings.TrimSpace(latest),
+		Local:       local,
+		Compare:     compare,
+		UpdateKind:  updateKind,
+		BehindCount: behindCount,
+		Message:     message,
+		Links:       links,
+		Highlights:  highlights,
+		Skipped:     skipped,
+		Cycle:       cycle.Name,
+		EOL:         cycle.EOL,
+		Advisories:  advisories,
+		LocalError:  strings.TrimSpace(localErr),
+		OSVError:    osvErr,
 	}
 	return res, state.Item{
 		LastCheckedAt: r.RunAt,
@@ -194,6 +280,19 @@ func (r runner) runOne(ctx context.Context, cfg config.TrackerEntry, prev state.
 	}
 }
 
+// shouldNotify applies notifyOn (or --min-severity) to an update of the given kind.
+// Updates of unknown kind (commits, PR state, non-version tags) are always reported.
+func (r runner) shouldNotify(cfg config.TrackerEntry, kind string) bool {
+	min := strings.TrimSpace(cfg.NotifyOn)
+	if min == "" {
+		min = strings.TrimSpace(r.Options.MinSeverity)
+	}
+	if min == "" || kind == "" {
+		return true
+	}
+	return version.AtLeast(kind, min)
+}
+
 func errorItem(cfg config.TrackerEntry, msg string) ReportItem {
 	return ReportItem{
 		Name:    cfg.Name,
@@ -238,7 +337,8 @@ func normalizeLatest(cfg config.TrackerEntry, current string) string {
 	if current == "" {
 		return ""
 	}
-	if cfg.Type == "github" && cfg.Mode == "release" {
+	// Release titles (github, gitlab) often carry more than the version.
+	if cfg.Mode == "release" {
 		if m := versionRe.FindString(current); m != "" {
 			return m
 		}
@@ -246,131 +346,114 @@ func normalizeLatest(cfg config.TrackerEntry, current string) string {
 	return current
 }
 
-func runLocalCheck(ctx context.Context, r runner, cfg config.TrackerEntry) (string, string) {
-	switch strings.TrimSpace(cfg.Local.Type) {
-	case "":
-		return "", ""
-	case "command":
-		// Use zsh -lc so the user's shell init is loaded (PATH, nvm, etc).
-		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
-		out, err := r.Registry.Exec.Run(attemptCtx, "zsh", "-lc", cfg.Local.Command)
-		cancel()
-		if err != nil {
-			return "", err.Error()
-		}
-		out = strings.TrimSpace(out)
-		if out == "" {
-			return "unknown", ""
-		}
-		if strings.TrimSpace(cfg.Local.Regex) == "" {
-			if m := versionRe.FindString(out); m != "" {
-				return m, ""
-			}
-			return "unknown", ""
-		}
-		re, err := regexp.Compile(cfg.Local.Regex)
-		if err != nil {
-			return "", "invalid local.regex: " + err.Error()
-		}
-		if m := re.FindString(out); m != "" {
-			return m, ""
-		}
-		return "unknown", ""
-	case "git":
-		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
-		out, err := r.Registry.Exec.Run(attemptCtx, "git", "-C", cfg.Local.Path, "rev-parse", "HEAD")
-		cancel()
-		if err != nil {
-			return "", err.Error()
-		}
-		out = strings.TrimSpace(out)
-		if out == "" {
-			return "unknown", ""
-		}
-		return out, ""
-	case "npm":
-		pkg := cfg.NpmPackage
-		if strings.TrimSpace(cfg.Local.Package) != "" {
-			pkg = cfg.Local.Package
-		}
-		if strings.TrimSpace(pkg) == "" {
-			return "", "missing npm package"
-		}
-		attemptCtx, cancel := context.WithTimeout(ctx, r.Timeout)
-		out, err := r.Registry.Exec.Run(attemptCtx, "npm", "list", pkg, "--depth=0", "-g", "--json")
-		cancel()
-
-		// npm returns non-zero for missing packages, but still prints JSON. Parse stdout first.
-		if v := parseNpmListJSON(out, pkg); v != "" {
-			return v, ""
-		}
-		if err != nil {
-			msg := strings.ToLower(err.Error())
-			if strings.Contains(msg, "missing") || strings.Contains(msg, "not installed") || strings.Contains(msg, "empty") {
-				return "not-installed", ""
-			}
-			return "", err.Error()
-		}
-		return "unknown", ""
-	default:
-		return "", "unknown local.type: " + cfg.Local.Type
-	}
-}
-
-func localUpdateAvailable(cfg config.TrackerEntry, local string, latest string) bool {
+// compareLocal reports how the local version relates to latest:
+// behind|equal|ahead, or "" when there is nothing to compare.
+func compareLocal(cfg config.TrackerEntry, local string, latest string) string {
 	local = strings.TrimSpace(local)
 	latest = strings.TrimSpace(latest)
 	if strings.TrimSpace(cfg.Local.Type) == "" {
-		return false
+		return ""
 	}
 	if local == "" || local == "unknown" {
-		return false
+		return ""
 	}
 	if latest == "" {
-		return false
+		return ""
 	}
-	switch cfg.Type {
-	case "github":
-		if cfg.Mode == "commit" {
-			// Allow comparing short to full SHA.
-			if strings.HasPrefix(latest, local) || strings.HasPrefix(local, latest) {
-				return false
-			}
-			return local != latest
+	if cfg.Mode == "commit" {
+		// Allow comparing short to full SHA. Commits can't be ordered, so any other SHA is "behind".
+		if strings.HasPrefix(latest, local) || strings.HasPrefix(local, latest) {
+			return compareEqual
 		}
-		return local != latest
-	case "npm":
-		if local == "not-installed" {
-			return true
+		return compareBehind
+	}
+	if local == "not-installed" {
+		return compareBehind
+	}
+	if isOSPackage(cfg) {
+		// Distro versions carry an epoch and a packaging revision
+		// (1:2.39.2-1ubuntu1); compare only the upstream part, dpkg-style.
+		upstream := version.DebianUpstream(local)
+		if _, ok := version.Parse(strings.ReplaceAll(upstream, "~", "-")); ok {
+			if v, ok := version.Parse(latest); ok {
+				return compareResult(version.CompareDebian(upstream, debianForm(v)))
+			}
 		}
-		return local != latest
-	default:
-		return local != latest
 	}
-}
 
-func parseNpmListJSON(out string, pkg string) string {
-	out = strings.TrimSpace(out)
-	if out == "" {
-		return ""
+	if c, ok := version.CompareStrings(local, latest); ok {
+		return compareResult(c)
 	}
-	type npmDep struct {
-		Version string `json:"version"`
+	// Not a version on one side: fall back to plain string comparison.
+	if local == latest {
+		return compareEqual
 	}
-	type npmList struct {
-		Dependencies map[string]npmDep `json:"dependencies"`
+	return compareBehind
+}
+
+func compareResult(c int) string {
+	switch {
+	case c < 0:
+		return compareBehind
+	case c > 0:
+		return compareAhead
+	default:
+		return compareEqual
 	}
+}
 
-	var parsed npmList
-	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
-		return ""
+func isOSPackage(cfg config.TrackerEntry) bool {
+	return cfg.Local.Type == "dpkg" || cfg.Local.Type == "rpm"
+}
+
+// debianForm writes a remote version the way a distro package would, so
+// prereleases sort first: 2.0.0-rc.1 -> 2.0.0~rc.1.
+func debianForm(v version.Version) string {
+	s := strings.TrimPrefix(strings.TrimPrefix(v.Raw, "v"), "V")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if v.IsPrerelease() {
+		if i := strings.IndexAny(s, "-_"); i >= 0 {
+			s = s[:i] + "~" + s[i+1:]
+		}
 	}
-	if parsed.Dependencies == nil {
-		return ""
+	return s
+}
+
+// localVersion maps a distro package version to its upstream version (with
+// ~rc1 as -rc1) for update kinds and counts; other locals are unchanged.
+func localVersion(cfg config.TrackerEntry, local string) string {
+	if !isOSPackage(cfg) || local == "not-installed" {
+		return local
 	}
-	dep, ok := parsed.Dependencies[pkg]
+	return strings.ReplaceAll(version.DebianUpstream(local), "~", "-")
+}
+
+// countBehind counts the distinct published versions in (local, latest].
+// It returns 0 when either side is not a version.
+func countBehind(local string, latest string, published []string) int {
+	lv, ok := version.Parse(local)
 	if !ok {
-		return ""
+		return 0
+	}
+	hv, ok := version.Extract(latest)
+	if !ok {
+		return 0
+	}
+	seen := map[string]bool{}
+	n := 0
+	for _, p := range published {
+		v, ok := version.Extract(p)
+		if !ok || version.Compare(v, lv) <= 0 || version.Compare(v, hv) > 0 {
+			continue
+		}
+		key := fmt.Sprint(v.Release, v.Post, v.Pre)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		n++
 	}
-	return strings.TrimSpace(dep.Version)
+	return n
 }
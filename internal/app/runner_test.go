@@ -0,0 +1,246 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peeomid/update-tracker/internal/config"
+	"github.com/peeomid/update-tracker/internal/osv"
+	"github.com/peeomid/update-tracker/internal/state"
+	"github.com/peeomid/update-tracker/internal/trackers"
+)
+
+func TestCompareLocal(t *testing.T) {
+	release := config.TrackerEntry{Type: "github", Mode: "release", Local: config.LocalEntry{Type: "command"}}
+	commit := config.TrackerEntry{Type: "github", Mode: "commit", Local: config.LocalEntry{Type: "git"}}
+	npm := config.TrackerEntry{Type: "npm", Local: config.LocalEntry{Type: "npm"}}
+	dpkg := config.TrackerEntry{Type: "github", Mode: "release", Local: config.LocalEntry{Type: "dpkg"}}
+	rpm := config.TrackerEntry{Type: "github", Mode: "release", Local: config.LocalEntry{Type: "rpm"}}
+	pip := config.TrackerEntry{Type: "pypi", Local: config.LocalEntry{Type: "pip"}}
+
+	cases := []struct {
+		cfg           config.TrackerEntry
+		local, latest string
+		want          string
+	}{
+		{release, "2.0.9", "2.1.0", compareBehind},
+		{release, "2.1.0-beta", "2.0.9", compareAhead},
+		{release, "v1.2.3", "1.2.3", compareEqual},
+		{release, "unknown", "1.2.3", ""},
+		{commit, "1006798", "1006798459a17e11903137ce09198e64686a4dbb", compareEqual},
+		{commit, "aaaaaaa", "bbbbbbb", compareBehind},
+		{npm, "not-installed", "5.0.0", compareBehind},
+		{npm, "5.0.0", "5.0.0", compareEqual},
+		{config.TrackerEntry{Type: "brew"}, "1.0.0", "2.0.0", ""},
+		{dpkg, "1:2.39.2-1ubuntu1", "v2.39.2", compareEqual},
+		{dpkg, "2.39.1-2", "2.39.2", compareBehind},
+		{dpkg, "7.0.1+dfsg-2", "7.0.1", compareEqual},
+		{dpkg, "2.0.0~rc1-1", "2.0.0", compareBehind},
+		{dpkg, "2.0.0~rc2-1", "2.0.0-rc1", compareAhead},
+		{dpkg, "2.1.0-1", "2.1.0-beta.1", compareAhead},
+		{rpm, "5.14.0-427.el9", "5.14.0", compareEqual},
+		{rpm, "2:8.2.2637-20.el9", "9.0.0", compareBehind},
+		{pip, "2.0.post1", "2.0", compareAhead},
+		{pip, "2.0", "2.0.post1", compareBehind},
+		{pip, "2.0.post1", "2.0.1", compareBehind},
+	}
+	for _, tc := range cases {
+		if got := compareLocal(tc.cfg, tc.local, tc.latest); got != tc.want {
+			t.Fatalf("%s %s vs %s: got %q want %q", tc.cfg.Type, tc.local, tc.latest, got, tc.want)
+		}
+	}
+}
+
+func TestCountBehind(t *testing.T) {
+	published := []string{"1.2.0", "1.2.1", "v1.3.0", "1.3.0", "1.4.0", "1.5.0", "1.6.0"}
+	if got := countBehind("1.2.0", "1.5.0", published); got != 4 {
+		t.Fatalf("got %d want 4", got)
+	}
+	if got := countBehind("abc", "1.5.0", published); got != 0 {
+		t.Fatalf("got %d want 0", got)
+	}
+	dpkg := config.TrackerEntry{Local: config.LocalEntry{Type: "dpkg"}}
+	if got := countBehind(localVersion(dpkg, "1:1.3.0-1ubuntu2"), "1.5.0", published); got != 2 {
+		t.Fatalf("dpkg: got %d want 2", got)
+	}
+}
+
+func TestMatchCycle(t *testing.T) {
+	cycles := []trackers.Cycle{{Name: "3.13"}, {Name: "3.12"}, {Name: "3.1"}, {Name: "3"}}
+	cases := map[string]string{
+		"3.12.1":  "3.12",
+		"v3.1.4":  "3.1",
+		"3.13":    "3.13",
+		"3.9.18":  "3",
+		"2.7.18":  "",
+		"unknown": "",
+	}
+	for local, want := range cases {
+		c, ok := matchCycle(cycles, local)
+		if c.Name != want || ok != (want != "") {
+			t.Fatalf("%s: got %q %t want %q", local, c.Name, ok, want)
+		}
+	}
+}
+
+func TestEOLStatus(t *testing.T) {
+	now := time.Date(2025, 5, 30, 15, 0, 0, 0, time.UTC)
+	cfg := config.TrackerEntry{Type: "eol", Product: "nodejs", Label: "Node"}
+	cases := []struct {
+		cycle      trackers.Cycle
+		warn       *int
+		wantStatus string
+		wantNote   string
+	}{
+		{trackers.Cycle{Name: "18", EOL: "2025-04-30"}, nil, statusEOL, "Node 18 reached EOL 30 days ago (2025-04-30)"},
+		{trackers.Cycle{Name: "23", EOL: "2025-06-01"}, nil, statusEOLSoon, "Node 23 reaches EOL in 2 days (2025-06-01)"},
+		{trackers.Cycle{Name: "23", EOL: "2025-06-01"}, intPtr(1), "", "Node 23 EOL 2025-06-01"},
+		{trackers.Cycle{Name: "23", EOL: "2025-06-01"}, intPtr(0), "", "Node 23 EOL 2025-06-01"},
+		{trackers.Cycle{Name: "18", EOL: "2025-04-30"}, intPtr(0), statusEOL, "Node 18 reached EOL 30 days ago (2025-04-30)"},
+		{trackers.Cycle{Name: "22", EOL: "2027-04-30"}, nil, "", "Node 22 EOL 2027-04-30"},
+		{trackers.Cycle{Name: "0.10", Ended: true}, nil, statusEOL, "Node 0.10 reached EOL"},
+		{trackers.Cycle{Name: "24"}, nil, "", "Node 24 has no EOL date yet"},
+	}
+	for _, tc := range cases {
+		cfg.EOLWarnDays = tc.warn
+		status, note := eolStatus(cfg, tc.cycle, now)
+		if status != tc.wantStatus || note != tc.wantNote {
+			t.Fatalf("%s: got %q %q want %q %q", tc.cycle.Name, status, note, tc.wantStatus, tc.wantNote)
+		}
+	}
+}
+
+func intPtr(n int) *int { return &n }
+
+type fakeExec map[string]string
+
+func (f fakeExec) Run(ctx context.Context, name string, args ...string) (string, error) {
+	key := strings.Join(append([]string{name}, args...), " ")
+	if out, ok := f[key]; ok {
+		return out, nil
+	}
+	return "", fmt.Errorf("unexpected command: %s", key)
+}
+
+type fakePoster struct {
+	Body []byte
+	Resp string
+}
+
+func (p *fakePoster) Post(ctx context.Context, url string, body []byte, headers map[string]string) ([]byte, error) {
+	p.Body = body
+	return []byte(p.Resp), nil
+}
+
+func TestRunOneVulnerable(t *testing.T) {
+	poster := &fakePoster{Resp: `{"vulns": [{"id": "GHSA-35jh-r3h4-6jhm", "database_specific": {"severity": "HIGH"},
+  "affected": [{"package": {"ecosystem": "npm", "name": "lodash"}, "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]}]}]}`}
+	r := runner{
+		Registry: trackers.Registry{Exec: fakeExec{
+			"npm view lodash --json":              `{"version": "4.17.21", "versions": ["4.17.15", "4.17.20", "4.17.21"]}`,
+			"npm list lodash --depth=0 -g --json": `{"dependencies": {"lodash": {"version": "4.17.15"}}}`,
+		}},
+		OSV:     osv.Client{HTTP: poster},
+		Timeout: time.Second,
+		Options: Options{OSV: true},
+	}
+	cfg := config.TrackerEntry{Name: "lodash", Type: "npm", Package: "lodash", Local: config.LocalEntry{Type: "npm"}}
+	item, st := r.runOne(context.Background(), cfg, state.Item{LastSeen: "4.17.21"})
+	if item.Status != statusVulnerable || st.LastStatus != statusVulnerable {
+		t.Fatalf("status=%q state=%q", item.Status, st.LastStatus)
+	}
+	if len(item.Advisories) != 1 || item.Advisories[0].FixedIn != "4.17.21" {
+		t.Fatalf("advisories=%+v", item.Advisories)
+	}
+	if want := "4.17.15 is affected by 1 advisory: GHSA-35jh-r3h4-6jhm (high, fixed in 4.17.21); latest 4.17.21"; item.Message != want {
+		t.Fatalf("message=%q", item.Message)
+	}
+	if !strings.Contains(string(poster.Body), `"version":"4.17.15"`) {
+		t.Fatalf("query=%s", poster.Body)
+	}
+
+	// Without OSV the same item is a plain update.
+	r.Options.OSV = false
+	if item, _ := r.runOne(context.Background(), cfg, state.Item{LastSeen: "4.17.21"}); item.Status != "update" || len(item.Advisories) != 0 {
+		t.Fatalf("status=%q advisories=%v", item.Status, item.Advisories)
+	}
+}
+
+func TestRunOneBrewRevisionIsEqual(t *testing.T) {
+	r := runner{
+		Registry: trackers.Registry{Exec: fakeExec{
+			"brew info --json=v2 ffmpeg": `{"formulae": [{"name": "ffmpeg", "versions": {"stable": "6.0"}, "linked_keg": "6.0_1", "installed": [{"version": "6.0_1"}]}], "casks": []}`,
+		}},
+		Timeout: time.Second,
+	}
+	cfg := config.TrackerEntry{Name: "ffmpeg", Type: "brew", Formula: "ffmpeg", Local: config.LocalEntry{Type: "brew"}}
+	item, _ := r.runOne(context.Background(), cfg, state.Item{LastSeen: "6.0"})
+	if item.Local != "6.0" || item.Compare != compareEqual || item.Status != "ok" {
+		t.Fatalf("local=%q compare=%q status=%q err=%q", item.Local, item.Compare, item.Status, item.Error)
+	}
+}
+
+func TestOSVVersion(t *testing.T) {
+	if got := osvVersion("GIT", "2.39.2", "v2.40.0"); got != "v2.39.2" {
+		t.Fatalf("got %q", got)
+	}
+	if got := osvVersion("Go", "v1.4.0", "v1.5.0"); got != "1.4.0" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestRunOneTagPatternUpdateKind(t *testing.T) {
+	r := runner{
+		Registry: trackers.Registry{Exec: fakeExec{
+			"git ls-remote --tags --refs https://example.com/mono.git": "aaa\trefs/tags/cli/v1.2.0\nbbb\trefs/tags/cli/v1.3.0\nccc\trefs/tags/web/v9.0.0\n",
+		}},
+		Timeout: time.Second,
+	}
+	cfg := config.TrackerEntry{Name: "cli", Type: "git", Mode: "tag", URL: "https://example.com/mono.git", TagPattern: `^cli/v(.+)$`}
+	item, _ := r.runOne(context.Background(), cfg, state.Item{LastSeen: "cli/v1.2.0"})
+	if item.Status != "update" || item.UpdateKind != "minor" {
+		t.Fatalf("status=%q kind=%q err=%q", item.Status, item.UpdateKind, item.Error)
+	}
+}
+
+type fakeFetcher map[string]string
+
+func (f fakeFetcher) Get(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
+	if body, ok := f[url]; ok {
+		return []byte(body), nil
+	}
+	return nil, fmt.Errorf("unexpected url: %s", url)
+}
+
+func TestRunOneEOLFollowsInstalledCycle(t *testing.T) {
+	r := runner{
+		Registry: trackers.Registry{
+			HTTP: fakeFetcher{"https://endoflife.date/api/nodejs.json": `[
+  {"cycle": "23", "latest": "23.2.0", "eol": "2025-06-01"},
+  {"cycle": "22", "latest": "22.11.0", "eol": "2027-04-30"}
+]`},
+			Exec: fakeExec{"zsh -lc node --version": "v22.11.0"},
+		},
+		Timeout: time.Second,
+		RunAt:   time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+	}
+	cfg := config.TrackerEntry{Name: "node", Type: "eol", Product: "nodejs", Local: config.LocalEntry{Type: "command", Command: "node --version"}}
+
+	// 23.2.0 is new, but only in a newer cycle.
+	item, st := r.runOne(context.Background(), cfg, state.Item{LastSeen: "22.11.0"})
+	if item.Status != "ok" || item.UpdateKind != "" || st.LastSeen != "22.11.0" {
+		t.Fatalf("status=%q kind=%q lastSeen=%q err=%q", item.Status, item.UpdateKind, st.LastSeen, item.Error)
+	}
+	if want := "nodejs 22 EOL 2027-04-30; latest 22.11.0 (cycle 22)"; item.Message != want {
+		t.Fatalf("message=%q", item.Message)
+	}
+
+	// A new release in the installed cycle is an update.
+	item, _ = r.runOne(context.Background(), cfg, state.Item{LastSeen: "22.10.0"})
+	if item.Status != "update" || item.UpdateKind != "minor" {
+		t.Fatalf("status=%q kind=%q", item.Status, item.UpdateKind)
+	}
+}
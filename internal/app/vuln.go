@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/config"
+	"github.com/peeomid/update-tracker/internal/osv"
+)
+
+const statusVulnerable = "vulnerable"
+
+// osvPackage maps a tracker to its OSV ecosystem and package name. GitHub
+// releases are looked up as GIT repositories by tag.
+func osvPackage(cfg config.TrackerEntry) (ecosystem string, name string, ok bool) {
+	switch cfg.Type {
+	case "npm":
+		return "npm", cfg.Package, true
+	case "pypi":
+		return "PyPI", cfg.Package, true
+	case "crates":
+		return "crates.io", cfg.Package, true
+	case "gomod":
+		return "Go", cfg.Module, true
+	case "maven":
+		return "Maven", cfg.Package, true
+	case "rubygems":
+		return "RubyGems", cfg.Package, true
+	case "packagist":
+		return "Packagist", cfg.Package, true
+	case "nuget":
+		return "NuGet", cfg.Package, true
+	case "github":
+		if cfg.Mode == "release" {
+			return "GIT", "https://github.com/" + cfg.Repo, true
+		}
+	}
+	return "", "", false
+}
+
+// osvVersion writes the local version the way the ecosystem does: registry
+// versions without "v", git tags with a "v" when the project's tags have one.
+func osvVersion(ecosystem string, local string, latestTag string) string {
+	local = strings.TrimPrefix(strings.TrimSpace(local), "v")
+	if ecosystem == "GIT" && strings.HasPrefix(strings.TrimSpace(latestTag), "v") {
+		return "v" + local
+	}
+	return local
+}
+
+// vulnNote summarizes advisories, e.g. "4.17.15 is affected by 2 advisories:
+// GHSA-35jh-r3h4-6jhm (critical, fixed in 4.17.21), ...". Only the first few
+// are listed; JSON output has all of them.
+func vulnNote(local string, advisories []osv.Advisory) string {
+	const maxListed = 3
+	noun := "advisories"
+	if len(advisories) == 1 {
+		noun = "advisory"
+	}
+	var parts []string
+	for i, a := range advisories {
+		if i == maxListed {
+			parts = append(parts, fmt.Sprintf("+%d more", len(advisories)-maxListed))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", a.ID, a.Detail()))
+	}
+	return fmt.Sprintf("%s is affected by %d %s: %s", local, len(advisories), noun, strings.Join(parts, ", "))
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/peeomid/update-tracker/internal/version"
 	"gopkg.in/yaml.v3"
 )
 
@@ -32,35 +34,109 @@ type TrackerEntry struct {
 	Group   string `yaml:"group"`
 	Display string `yaml:"display"`
 
-	// github
+	// notify (optional): minimum update kind to report, major|minor|patch|prerelease
+	NotifyOn string `yaml:"notifyOn"`
+
+	// version constraint (optional), e.g. ^2.0, ~1.4, <3; for trackers that
+	// follow versions (not commit/pr modes)
+	Constraint string `yaml:"constraint"`
+	// prereleases are skipped unless enabled (same types as constraint)
+	IncludePrereleases bool `yaml:"includePrereleases"`
+	// release tag filters (optional; forge release modes, git tag, oci): regex;
+	// a "version" group (or the first group) in tagPattern is the version
+	TagPattern string `yaml:"tagPattern"`
+	TagExclude string `yaml:"tagExclude"`
+
+	// github, gitlab, gitea (repo is the project path; pr is the merge request iid for gitlab mr)
 	Mode   string `yaml:"mode"`
 	Repo   string `yaml:"repo"`
 	Branch string `yaml:"branch"`
 	PR     int    `yaml:"pr"`
 
-	// brew
+	// gitlab, gitea: instance URL (default https://gitlab.com, https://codeberg.org)
+	// and the env var holding an access token (default GITLAB_TOKEN, GITEA_TOKEN)
+	BaseURL  string `yaml:"baseURL"`
+	TokenEnv string `yaml:"tokenEnv"`
+
+	// brew: formula or cask name, optionally tap-qualified (owner/tap/name)
 	Formula string `yaml:"formula"`
+	Cask    bool   `yaml:"cask"`
+
+	// npm, pypi, crates, rubygems, nuget; packagist: vendor/name; maven: groupId:artifactId
+	Package string `yaml:"package"`
+
+	// gomod
+	Module string `yaml:"module"`
+
+	// eol: endoflife.date product (nodejs, python, postgresql, ubuntu) and how
+	// many days before a cycle's end of life to report eol-soon (default 90,
+	// 0 disables eol-soon)
+	Product     string `yaml:"product"`
+	EOLWarnDays *int   `yaml:"eolWarnDays"`
+
+	// terraform: provider (namespace/type) or module (namespace/name/provider)
+	// address, optionally prefixed with the registry host; mode is provider|module
+	Source string `yaml:"source"`
+
+	// git: remote URL (https, ssh, file://); mode commit follows branch, mode
+	// tag follows the newest version tag. http: JSON endpoint URL. feed: RSS
+	// or Atom URL. page: HTML page URL. helm: chart repository URL.
+	URL string `yaml:"url"`
+
+	// helm: chart name in the repository index
+	Chart string `yaml:"chart"`
+
+	// feed, page: regex on item titles / page text; a "version" group (or the
+	// first group) is what gets tracked
+	Pattern string `yaml:"pattern"`
 
-	// npm
-	NpmPackage string `yaml:"package"`
+	// page: element selector (tag, #id, .class, [attr=value]) and whether to
+	// track a hash of the selected content instead of its text
+	Selector string `yaml:"selector"`
+	Hash     bool   `yaml:"hash"`
+
+	// http: request headers (values may use $ENV_VAR), JSONPath or dotted path
+	// to the version (or a list of versions), and optional path to a link
+	Headers  map[string]string `yaml:"headers"`
+	JSONPath string            `yaml:"jsonPath"`
+	LinkPath string            `yaml:"linkPath"`
+
+	// oci: image reference (postgres, ghcr.io/org/app, optionally with :tag) and
+	// the moving tag whose digest is tracked (optional)
+	Image string `yaml:"image"`
+	Tag   string `yaml:"tag"`
+
+	// package registry base URL (optional; pypi: https://pypi.org, crates: https://crates.io,
+	// gomod: GOPROXY base, default https://proxy.golang.org; oci: derived from image;
+	// maven: repository root, default https://repo1.maven.org/maven2; terraform:
+	// default from source host, else https://registry.terraform.io; rubygems:
+	// https://rubygems.org; packagist: https://repo.packagist.org; nuget:
+	// registration base address, default https://api.nuget.org/v3/registration5-gz-semver2)
+	Registry string `yaml:"registry"`
 
 	// local checks (optional)
 	Local LocalEntry `yaml:"local"`
 }
 
 type LocalEntry struct {
-	// one of: command|git|npm
+	// one of: command|git|npm|pip|cargo|gobinary|docker|brew|helm|terraform|dpkg|rpm
+	// (dpkg and rpm work with any tracker that follows versions)
 	Type string `yaml:"type"`
 
 	// command
 	Command string `yaml:"command"`
 	Regex   string `yaml:"regex"`
 
-	// git
+	// git (clone dir; HEAD, or the nearest tag for git tag trackers), gobinary
+	// (binary path), terraform (directory with .terraform.lock.hcl)
 	Path string `yaml:"path"`
 
-	// npm
+	// npm, pip, cargo, dpkg, rpm
 	Package string `yaml:"package"`
+
+	// helm: release name (default: the chart name) and namespace (default: all)
+	Release   string `yaml:"release"`
+	Namespace string `yaml:"namespace"`
 }
 
 func ResolvePath(p string) string {
@@ -173,6 +249,39 @@ func (c Config) Validate() error {
 			}
 		}
 
+		if strings.TrimSpace(t.NotifyOn) != "" && !version.ValidKind(t.NotifyOn) {
+			return fmt.Errorf("config: trackers[%d].notifyOn must be major|minor|patch|prerelease (or empty)", i)
+		}
+
+		if strings.TrimSpace(t.Constraint) != "" {
+			if _, err := version.ParseConstraint(t.Constraint); err != nil {
+				return fmt.Errorf("config: trackers[%d].constraint: %w", i, err)
+			}
+			if !t.tracksVersions() {
+				return fmt.Errorf("config: trackers[%d].constraint not allowed for %s", i, t.kind())
+			}
+		}
+		if t.IncludePrereleases && !t.tracksVersions() {
+			return fmt.Errorf("config: trackers[%d].includePrereleases not allowed for %s", i, t.kind())
+		}
+		for _, f := range []struct{ key, pattern string }{{"tagPattern", t.TagPattern}, {"tagExclude", t.TagExclude}} {
+			key, pattern := f.key, f.pattern
+			if strings.TrimSpace(pattern) == "" {
+				continue
+			}
+			if !t.tracksVersions() || !(hasModes(t.Type) || t.Type == "git" || t.Type == "oci") {
+				return fmt.Errorf("config: trackers[%d].%s only allowed for github/gitlab/gitea release, git tag or oci", i, key)
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("config: trackers[%d].%s: %w", i, key, err)
+			}
+		}
+
+		// OS package checks compare with any tracker that follows versions.
+		if (t.Local.Type == "dpkg" || t.Local.Type == "rpm") && !t.tracksVersions() {
+			return fmt.Errorf("config: trackers[%d].local.type %s not allowed for %s", i, t.Local.Type, t.kind())
+		}
+
 		switch t.Type {
 		case "github":
 			if strings.TrimSpace(t.Repo) == "" {
@@ -181,8 +290,8 @@ func (c Config) Validate() error {
 			if t.Mode != "release" && t.Mode != "commit" && t.Mode != "pr" {
 				return fmt.Errorf("config: trackers[%d].mode must be release|commit|pr (github)", i)
 			}
-			if strings.TrimSpace(t.Formula) != "" || strings.TrimSpace(t.NpmPackage) != "" {
-				return fmt.Errorf("config: trackers[%d] has fields not allowed for github", i)
+			if f := t.extraField("mode", "repo", "branch", "pr"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for github", i, f)
 			}
 
 			switch t.Mode {
@@ -205,13 +314,11 @@ func (c Config) Validate() error {
 				if t.PR != 0 {
 					return fmt.Errorf("config: trackers[%d].pr not allowed for github release", i)
 				}
-				if strings.TrimSpace(t.Local.Type) != "" {
-					if t.Local.Type != "command" {
-						return fmt.Errorf("config: trackers[%d].local.type must be command (github release)", i)
-					}
-					if strings.TrimSpace(t.Local.Command) == "" {
-						return fmt.Errorf("config: trackers[%d].local.command is required (github release)", i)
-					}
+				if !t.localTypeIn("command", "dpkg", "rpm") {
+					return fmt.Errorf("config: trackers[%d].local.type must be command|dpkg|rpm (github release)", i)
+				}
+				if t.Local.Type == "command" && strings.TrimSpace(t.Local.Command) == "" {
+					return fmt.Errorf("config: trackers[%d].local.command is required (github release)", i)
 				}
 			case "pr":
 				if t.PR <= 0 {
@@ -224,6 +331,145 @@ func (c Config) Validate() error {
 					return fmt.Errorf("config: trackers[%d].local not supported for github pr", i)
 				}
 			}
+		case "gitlab", "gitea":
+			prMode := "pr"
+			if t.Type == "gitlab" {
+				prMode = "mr"
+			}
+			if strings.TrimSpace(t.Repo) == "" {
+				return fmt.Errorf("config: trackers[%d].repo is required (%s)", i, t.Type)
+			}
+			if f := t.extraField("mode", "repo", "branch", "pr", "baseURL", "tokenEnv"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for %s", i, f, t.Type)
+			}
+			switch t.Mode {
+			case "release":
+				if t.PR != 0 {
+					return fmt.Errorf("config: trackers[%d].pr not allowed for %s release", i, t.Type)
+				}
+				if !t.localTypeIn("command", "dpkg", "rpm") {
+					return fmt.Errorf("config: trackers[%d].local.type must be command|dpkg|rpm (%s release)", i, t.Type)
+				}
+			case "commit":
+				if strings.TrimSpace(t.Branch) == "" {
+					return fmt.Errorf("config: trackers[%d].branch is required (%s commit)", i, t.Type)
+				}
+				if t.PR != 0 {
+					return fmt.Errorf("config: trackers[%d].pr not allowed for %s commit", i, t.Type)
+				}
+				if l := strings.TrimSpace(t.Local.Type); l != "" && l != "git" {
+					return fmt.Errorf("config: trackers[%d].local.type must be git (%s commit)", i, t.Type)
+				}
+			case prMode:
+				if t.PR <= 0 {
+					return fmt.Errorf("config: trackers[%d].pr is required and must be > 0 (%s %s)", i, t.Type, prMode)
+				}
+				if strings.TrimSpace(t.Branch) != "" {
+					return fmt.Errorf("config: trackers[%d].branch not allowed for %s %s", i, t.Type, prMode)
+				}
+				if strings.TrimSpace(t.Local.Type) != "" {
+					return fmt.Errorf("config: trackers[%d].local not supported for %s %s", i, t.Type, prMode)
+				}
+			default:
+				return fmt.Errorf("config: trackers[%d].mode must be release|commit|%s (%s)", i, prMode, t.Type)
+			}
+		case "git":
+			if strings.TrimSpace(t.URL) == "" {
+				return fmt.Errorf("config: trackers[%d].url is required (git)", i)
+			}
+			if f := t.extraField("mode", "url", "branch"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for git", i, f)
+			}
+			switch t.Mode {
+			case "commit":
+				if strings.TrimSpace(t.Branch) == "" {
+					return fmt.Errorf("config: trackers[%d].branch is required (git commit)", i)
+				}
+				if l := strings.TrimSpace(t.Local.Type); l != "" && l != "git" {
+					return fmt.Errorf("config: trackers[%d].local.type must be git (git commit)", i)
+				}
+			case "tag":
+				if strings.TrimSpace(t.Branch) != "" {
+					return fmt.Errorf("config: trackers[%d].branch not allowed for git tag", i)
+				}
+				if !t.localTypeIn("git", "command", "dpkg", "rpm") {
+					return fmt.Errorf("config: trackers[%d].local.type must be git|command|dpkg|rpm (git tag)", i)
+				}
+			default:
+				return fmt.Errorf("config: trackers[%d].mode must be commit|tag (git)", i)
+			}
+		case "http":
+			if strings.TrimSpace(t.URL) == "" {
+				return fmt.Errorf("config: trackers[%d].url is required (http)", i)
+			}
+			if strings.TrimSpace(t.JSONPath) == "" {
+				return fmt.Errorf("config: trackers[%d].jsonPath is required (http)", i)
+			}
+			if f := t.extraField("url", "headers", "jsonPath", "linkPath"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for http", i, f)
+			}
+			if !t.localTypeIn("command", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be command|dpkg|rpm (http)", i)
+			}
+		case "feed":
+			if strings.TrimSpace(t.URL) == "" {
+				return fmt.Errorf("config: trackers[%d].url is required (feed)", i)
+			}
+			if f := t.extraField("url", "pattern"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for feed", i, f)
+			}
+			if strings.TrimSpace(t.Pattern) != "" {
+				if _, err := regexp.Compile(t.Pattern); err != nil {
+					return fmt.Errorf("config: trackers[%d].pattern: %w", i, err)
+				}
+			}
+			if strings.TrimSpace(t.Local.Type) != "" {
+				if !t.localTypeIn("command", "dpkg", "rpm") {
+					return fmt.Errorf("config: trackers[%d].local.type must be command|dpkg|rpm (feed)", i)
+				}
+				if strings.TrimSpace(t.Pattern) == "" {
+					return fmt.Errorf("config: trackers[%d].pattern is required for local (feed)", i)
+				}
+			}
+		case "page":
+			if strings.TrimSpace(t.URL) == "" {
+				return fmt.Errorf("config: trackers[%d].url is required (page)", i)
+			}
+			if strings.TrimSpace(t.Pattern) == "" && strings.TrimSpace(t.Selector) == "" && !t.Hash {
+				return fmt.Errorf("config: trackers[%d] needs pattern, selector or hash (page)", i)
+			}
+			if f := t.extraField("url", "pattern", "selector", "hash"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for page", i, f)
+			}
+			if strings.TrimSpace(t.Pattern) != "" {
+				if _, err := regexp.Compile(t.Pattern); err != nil {
+					return fmt.Errorf("config: trackers[%d].pattern: %w", i, err)
+				}
+			}
+			if strings.TrimSpace(t.Local.Type) != "" {
+				if !t.localTypeIn("command", "dpkg", "rpm") {
+					return fmt.Errorf("config: trackers[%d].local.type must be command|dpkg|rpm (page)", i)
+				}
+				if t.Hash {
+					return fmt.Errorf("config: trackers[%d].local not supported with hash (page)", i)
+				}
+			}
+		case "helm":
+			if strings.TrimSpace(t.URL) == "" || strings.TrimSpace(t.Chart) == "" {
+				return fmt.Errorf("config: trackers[%d].url and chart are required (helm)", i)
+			}
+			if !strings.HasPrefix(t.URL, "https://") && !strings.HasPrefix(t.URL, "http://") {
+				return fmt.Errorf("config: trackers[%d].url must be an http(s) chart repository (helm; oci:// charts: use type oci)", i)
+			}
+			if strings.TrimSpace(t.Mode) != "" {
+				return fmt.Errorf("config: trackers[%d].mode not allowed for type helm", i)
+			}
+			if f := t.extraField("url", "chart"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for helm", i, f)
+			}
+			if !t.localTypeIn("helm", "command", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be helm|command|dpkg|rpm (helm)", i)
+			}
 		case "brew":
 			if strings.TrimSpace(t.Formula) == "" {
 				return fmt.Errorf("config: trackers[%d].formula is required (brew)", i)
@@ -231,14 +477,14 @@ func (c Config) Validate() error {
 			if strings.TrimSpace(t.Mode) != "" {
 				return fmt.Errorf("config: trackers[%d].mode not allowed for type brew", i)
 			}
-			if strings.TrimSpace(t.Repo) != "" || strings.TrimSpace(t.Branch) != "" || strings.TrimSpace(t.NpmPackage) != "" || t.PR != 0 {
-				return fmt.Errorf("config: trackers[%d] has fields not allowed for brew", i)
+			if f := t.extraField("formula", "cask"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for brew", i, f)
 			}
-			if strings.TrimSpace(t.Local.Type) != "" {
-				return fmt.Errorf("config: trackers[%d].local not supported for brew", i)
+			if !t.localTypeIn("brew", "command", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be brew|command|dpkg|rpm (brew)", i)
 			}
 		case "npm":
-			if strings.TrimSpace(t.NpmPackage) == "" {
+			if strings.TrimSpace(t.Package) == "" {
 				return fmt.Errorf("config: trackers[%d].package is required (npm)", i)
 			}
 			if strings.TrimSpace(t.Mode) != "" {
@@ -248,19 +494,151 @@ func (c Config) Validate() error {
 				return fmt.Errorf("config: trackers[%d] has fields not allowed for npm", i)
 			}
 			if strings.TrimSpace(t.Local.Type) != "" {
-				if t.Local.Type != "npm" {
-					return fmt.Errorf("config: trackers[%d].local.type must be npm (npm)", i)
+				if !t.localTypeIn("npm", "dpkg", "rpm") {
+					return fmt.Errorf("config: trackers[%d].local.type must be npm|dpkg|rpm (npm)", i)
 				}
-				if strings.TrimSpace(t.Local.Package) != "" && strings.TrimSpace(t.Local.Package) != strings.TrimSpace(t.NpmPackage) {
+				if strings.TrimSpace(t.Local.Package) != "" && strings.TrimSpace(t.Local.Package) != strings.TrimSpace(t.Package) {
 					// allowed, but must be explicit and non-empty; keep it validated (no extra rule)
 				}
 			}
+		case "pypi":
+			if strings.TrimSpace(t.Package) == "" {
+				return fmt.Errorf("config: trackers[%d].package is required (pypi)", i)
+			}
+			if f := t.extraField("package", "registry"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for pypi", i, f)
+			}
+			if !t.localTypeIn("pip", "command", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be pip|command|dpkg|rpm (pypi)", i)
+			}
+		case "crates":
+			if strings.TrimSpace(t.Package) == "" {
+				return fmt.Errorf("config: trackers[%d].package is required (crates)", i)
+			}
+			if f := t.extraField("package", "registry"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for crates", i, f)
+			}
+			if !t.localTypeIn("cargo", "command", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be cargo|command|dpkg|rpm (crates)", i)
+			}
+		case "rubygems", "packagist", "nuget":
+			if strings.TrimSpace(t.Package) == "" {
+				return fmt.Errorf("config: trackers[%d].package is required (%s)", i, t.Type)
+			}
+			if t.Type == "packagist" && strings.Count(t.Package, "/") != 1 {
+				return fmt.Errorf("config: trackers[%d].package must be vendor/name (packagist)", i)
+			}
+			if f := t.extraField("package", "registry"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for %s", i, f, t.Type)
+			}
+			if !t.localTypeIn("command", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be command|dpkg|rpm (%s)", i, t.Type)
+			}
+		case "maven":
+			group, artifact, ok := strings.Cut(strings.TrimSpace(t.Package), ":")
+			if !ok || strings.TrimSpace(group) == "" || strings.TrimSpace(artifact) == "" {
+				return fmt.Errorf("config: trackers[%d].package must be groupId:artifactId (maven)", i)
+			}
+			if f := t.extraField("package", "registry"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for maven", i, f)
+			}
+			if !t.localTypeIn("command", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be command|dpkg|rpm (maven)", i)
+			}
+		case "eol":
+			if strings.TrimSpace(t.Product) == "" {
+				return fmt.Errorf("config: trackers[%d].product is required (eol)", i)
+			}
+			if f := t.extraField("product", "eolWarnDays", "registry"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for eol", i, f)
+			}
+			if t.EOLWarnDays != nil && *t.EOLWarnDays < 0 {
+				return fmt.Errorf("config: trackers[%d].eolWarnDays must be >= 0", i)
+			}
+			// Cycles are reported as published; there is nothing to filter.
+			if strings.TrimSpace(t.Constraint) != "" {
+				return fmt.Errorf("config: trackers[%d].constraint not allowed for eol", i)
+			}
+			if t.IncludePrereleases {
+				return fmt.Errorf("config: trackers[%d].includePrereleases not allowed for eol", i)
+			}
+			// Any local check that yields the product's version works; package-based
+			// ones need local.package since the tracker has none. gobinary is left
+			// out: it reports a module version, not a product one.
+			if !t.localTypeIn("command", "npm", "pip", "cargo", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be command|npm|pip|cargo|dpkg|rpm (eol)", i)
+			}
+			switch t.Local.Type {
+			case "npm", "pip", "cargo":
+				if strings.TrimSpace(t.Local.Package) == "" {
+					return fmt.Errorf("config: trackers[%d].local.package is required (eol)", i)
+				}
+			}
+		case "terraform":
+			if t.Mode != "provider" && t.Mode != "module" {
+				return fmt.Errorf("config: trackers[%d].mode must be provider|module (terraform)", i)
+			}
+			want, form := 2, "namespace/type"
+			if t.Mode == "module" {
+				want, form = 3, "namespace/name/provider"
+			}
+			parts := strings.Split(strings.Trim(strings.TrimSpace(t.Source), "/"), "/")
+			if strings.Contains(parts[0], ".") {
+				parts = parts[1:]
+			}
+			if len(parts) != want {
+				return fmt.Errorf("config: trackers[%d].source must be [host/]%s (terraform %s)", i, form, t.Mode)
+			}
+			if f := t.extraField("mode", "source", "registry"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for terraform", i, f)
+			}
+			if t.Mode == "provider" && !t.localTypeIn("terraform", "command", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be terraform|command|dpkg|rpm (terraform provider)", i)
+			}
+			if t.Mode == "module" && !t.localTypeIn("command", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be command|dpkg|rpm (terraform module)", i)
+			}
+		case "gomod":
+			if strings.TrimSpace(t.Module) == "" {
+				return fmt.Errorf("config: trackers[%d].module is required (gomod)", i)
+			}
+			if f := t.extraField("module", "registry"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for gomod", i, f)
+			}
+			if !t.localTypeIn("gobinary", "command", "dpkg", "rpm") {
+				return fmt.Errorf("config: trackers[%d].local.type must be gobinary|command|dpkg|rpm (gomod)", i)
+			}
+		case "oci":
+			image, tag := t.ImageRef()
+			if image == "" {
+				return fmt.Errorf("config: trackers[%d].image is required (oci)", i)
+			}
+			if tag == "" && strings.TrimSpace(t.TagPattern) == "" {
+				return fmt.Errorf("config: trackers[%d] needs tag or tagPattern (oci)", i)
+			}
+			if f := t.extraField("image", "tag", "registry"); f != "" {
+				return fmt.Errorf("config: trackers[%d].%s not allowed for oci", i, f)
+			}
+			if l := strings.TrimSpace(t.Local.Type); l != "" {
+				if !t.localTypeIn("docker", "dpkg", "rpm") {
+					return fmt.Errorf("config: trackers[%d].local.type must be docker|dpkg|rpm (oci)", i)
+				}
+				if l == "docker" && tag == "" {
+					return fmt.Errorf("config: trackers[%d].tag is required for local docker (oci)", i)
+				}
+				if l != "docker" && tag != "" {
+					return fmt.Errorf("config: trackers[%d].local.type %s not allowed with tag (oci)", i, l)
+				}
+			}
 		default:
-			return fmt.Errorf("config: trackers[%d].type must be github|brew|npm", i)
+			return fmt.Errorf("config: trackers[%d].type must be github|gitlab|gitea|git|http|feed|page|brew|helm|npm|pypi|crates|rubygems|packagist|nuget|maven|gomod|terraform|eol|oci", i)
 		}
 
 		// validate local fields (no extra keys)
 		if strings.TrimSpace(t.Local.Type) != "" {
+			if t.Local.Type != "helm" && (strings.TrimSpace(t.Local.Release) != "" || strings.TrimSpace(t.Local.Namespace) != "") {
+				return fmt.Errorf("config: trackers[%d].local release/namespace only allowed for helm", i)
+			}
 			switch t.Local.Type {
 			case "command":
 				if strings.TrimSpace(t.Local.Command) == "" {
@@ -276,15 +654,137 @@ func (c Config) Validate() error {
 				if strings.TrimSpace(t.Local.Command) != "" || strings.TrimSpace(t.Local.Regex) != "" || strings.TrimSpace(t.Local.Package) != "" {
 					return fmt.Errorf("config: trackers[%d].local has fields not allowed for git", i)
 				}
-			case "npm":
+			case "gobinary", "terraform":
+				if strings.TrimSpace(t.Local.Path) == "" {
+					return fmt.Errorf("config: trackers[%d].local.path is required (%s)", i, t.Local.Type)
+				}
+				if strings.TrimSpace(t.Local.Command) != "" || strings.TrimSpace(t.Local.Regex) != "" || strings.TrimSpace(t.Local.Package) != "" {
+					return fmt.Errorf("config: trackers[%d].local has fields not allowed for %s", i, t.Local.Type)
+				}
+			case "dpkg", "rpm":
+				if strings.TrimSpace(t.Local.Package) == "" {
+					return fmt.Errorf("config: trackers[%d].local.package is required (%s)", i, t.Local.Type)
+				}
+				if strings.TrimSpace(t.Local.Command) != "" || strings.TrimSpace(t.Local.Regex) != "" || strings.TrimSpace(t.Local.Path) != "" {
+					return fmt.Errorf("config: trackers[%d].local has fields not allowed for %s", i, t.Local.Type)
+				}
+			case "docker", "brew", "helm":
+				if strings.TrimSpace(t.Local.Command) != "" || strings.TrimSpace(t.Local.Regex) != "" || strings.TrimSpace(t.Local.Path) != "" || strings.TrimSpace(t.Local.Package) != "" {
+					return fmt.Errorf("config: trackers[%d].local has fields not allowed for %s", i, t.Local.Type)
+				}
+			case "npm", "pip", "cargo":
 				if strings.TrimSpace(t.Local.Command) != "" || strings.TrimSpace(t.Local.Regex) != "" || strings.TrimSpace(t.Local.Path) != "" {
-					return fmt.Errorf("config: trackers[%d].local has fields not allowed for npm", i)
+					return fmt.Errorf("config: trackers[%d].local has fields not allowed for %s", i, t.Local.Type)
 				}
 			default:
-				return fmt.Errorf("config: trackers[%d].local.type must be command|git|npm", i)
+				return fmt.Errorf("config: trackers[%d].local.type must be command|git|npm|pip|cargo|gobinary|docker|brew|helm|terraform|dpkg|rpm", i)
 			}
 		}
 	}
 
 	return nil
 }
+
+// hasModes reports whether the type is a forge with release|commit|... modes.
+func hasModes(typ string) bool {
+	return typ == "github" || typ == "gitlab" || typ == "gitea"
+}
+
+// tracksVersions reports whether the tracker follows released versions, so
+// constraints and tag filters apply (not commits or PR state).
+func (t TrackerEntry) tracksVersions() bool {
+	switch {
+	case hasModes(t.Type):
+		return t.Mode == "release"
+	case t.Type == "git":
+		return t.Mode == "tag"
+	case t.Type == "feed":
+		return strings.TrimSpace(t.Pattern) != ""
+	case t.Type == "page":
+		return !t.Hash
+	}
+	return true
+}
+
+// localTypeIn reports whether local.type is unset or one of types.
+func (t TrackerEntry) localTypeIn(types ...string) bool {
+	l := strings.TrimSpace(t.Local.Type)
+	if l == "" {
+		return true
+	}
+	for _, typ := range types {
+		if l == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// kind is the type and mode for messages, e.g. "github commit".
+func (t TrackerEntry) kind() string {
+	return strings.TrimSpace(t.Type + " " + t.Mode)
+}
+
+// ImageRef returns the oci image without tag, and the tag from `tag` or from
+// an "image:tag" reference.
+func (t TrackerEntry) ImageRef() (string, string) {
+	image := strings.TrimSpace(t.Image)
+	tag := strings.TrimSpace(t.Tag)
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		if tag == "" {
+			tag = image[i+1:]
+		}
+		image = image[:i]
+	}
+	return image, tag
+}
+
+// extraField returns the yaml name of the first source-specific field that is
+// set but not in allowed, or "" if there is none.
+func (t TrackerEntry) extraField(allowed ...string) string {
+	fields := []struct {
+		name string
+		set  bool
+	}{
+		{"mode", strings.TrimSpace(t.Mode) != ""},
+		{"repo", strings.TrimSpace(t.Repo) != ""},
+		{"branch", strings.TrimSpace(t.Branch) != ""},
+		{"pr", t.PR != 0},
+		{"formula", strings.TrimSpace(t.Formula) != ""},
+		{"cask", t.Cask},
+		{"package", strings.TrimSpace(t.Package) != ""},
+		{"module", strings.TrimSpace(t.Module) != ""},
+		{"source", strings.TrimSpace(t.Source) != ""},
+		{"product", strings.TrimSpace(t.Product) != ""},
+		{"eolWarnDays", t.EOLWarnDays != nil},
+		{"url", strings.TrimSpace(t.URL) != ""},
+		{"chart", strings.TrimSpace(t.Chart) != ""},
+		{"headers", len(t.Headers) > 0},
+		{"jsonPath", strings.TrimSpace(t.JSONPath) != ""},
+		{"linkPath", strings.TrimSpace(t.LinkPath) != ""},
+		{"pattern", strings.TrimSpace(t.Pattern) != ""},
+		{"selector", strings.TrimSpace(t.Selector) != ""},
+		{"hash", t.Hash},
+		{"image", strings.TrimSpace(t.Image) != ""},
+		{"tag", strings.TrimSpace(t.Tag) != ""},
+		{"registry", strings.TrimSpace(t.Registry) != ""},
+		{"baseURL", strings.TrimSpace(t.BaseURL) != ""},
+		{"tokenEnv", strings.TrimSpace(t.TokenEnv) != ""},
+	}
+	for _, f := range fields {
+		if !f.set {
+			continue
+		}
+		ok := false
+		for _, a := range allowed {
+			if a == f.name {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return f.name
+		}
+	}
+	return ""
+}
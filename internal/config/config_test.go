@@ -2,6 +2,7 @@ package config
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -37,3 +38,103 @@ func TestValidate_MissingRequired(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+// validTracker wraps t in an otherwise valid config.
+func validTracker(t TrackerEntry) Config {
+	t.Name = "x"
+	return Config{
+		Version:  1,
+		Defaults: Defaults{TimeoutSeconds: 10, Concurrency: 1, UserAgent: "x"},
+		Trackers: []TrackerEntry{t},
+	}
+}
+
+func TestValidate_LocalTypes(t *testing.T) {
+	// Each local type with the fields it requires.
+	locals := map[string]LocalEntry{
+		"command":   {Type: "command", Command: "x --version"},
+		"git":       {Type: "git", Path: "/src/x"},
+		"npm":       {Type: "npm", Package: "x"},
+		"pip":       {Type: "pip", Package: "x"},
+		"cargo":     {Type: "cargo", Package: "x"},
+		"gobinary":  {Type: "gobinary", Path: "/usr/local/bin/x"},
+		"docker":    {Type: "docker"},
+		"brew":      {Type: "brew"},
+		"helm":      {Type: "helm", Release: "x"},
+		"terraform": {Type: "terraform", Path: "/src/infra"},
+		"dpkg":      {Type: "dpkg", Package: "x"},
+		"rpm":       {Type: "rpm", Package: "x"},
+	}
+	cases := []struct {
+		tracker TrackerEntry
+		allowed []string
+	}{
+		{TrackerEntry{Type: "github", Mode: "release", Repo: "a/b"}, []string{"command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "github", Mode: "commit", Repo: "a/b", Branch: "main"}, []string{"git"}},
+		{TrackerEntry{Type: "github", Mode: "pr", Repo: "a/b", PR: 1}, nil},
+		{TrackerEntry{Type: "gitlab", Mode: "release", Repo: "a/b"}, []string{"command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "gitea", Mode: "commit", Repo: "a/b", Branch: "main"}, []string{"git"}},
+		{TrackerEntry{Type: "git", Mode: "tag", URL: "https://example.com/x.git"}, []string{"git", "command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "git", Mode: "commit", URL: "https://example.com/x.git", Branch: "main"}, []string{"git"}},
+		{TrackerEntry{Type: "http", URL: "https://example.com/v", JSONPath: "version"}, []string{"command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "feed", URL: "https://example.com/feed", Pattern: `v(\S+)`}, []string{"command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "page", URL: "https://example.com/", Pattern: `v(\S+)`}, []string{"command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "helm", URL: "https://charts.example.com", Chart: "x"}, []string{"helm", "command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "brew", Formula: "x"}, []string{"brew", "command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "npm", Package: "x"}, []string{"npm", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "pypi", Package: "x"}, []string{"pip", "command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "crates", Package: "x"}, []string{"cargo", "command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "nuget", Package: "x"}, []string{"command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "maven", Package: "g:a"}, []string{"command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "gomod", Module: "example.com/x"}, []string{"gobinary", "command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "terraform", Mode: "provider", Source: "hashicorp/aws"}, []string{"terraform", "command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "terraform", Mode: "module", Source: "a/b/aws"}, []string{"command", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "eol", Product: "nodejs"}, []string{"command", "npm", "pip", "cargo", "dpkg", "rpm"}},
+		{TrackerEntry{Type: "oci", Image: "nginx:1.27"}, []string{"docker"}},
+		{TrackerEntry{Type: "oci", Image: "nginx", TagPattern: `^\d+\.\d+$`}, []string{"dpkg", "rpm"}},
+	}
+	for _, tc := range cases {
+		name := tc.tracker.Type + " " + tc.tracker.Mode
+		if err := validTracker(tc.tracker).Validate(); err != nil {
+			t.Fatalf("%s without local: %v", name, err)
+		}
+		for typ, local := range locals {
+			tr := tc.tracker
+			tr.Local = local
+			want := false
+			for _, a := range tc.allowed {
+				want = want || a == typ
+			}
+			err := validTracker(tr).Validate()
+			if want && err != nil {
+				t.Fatalf("%s with local %s: %v", name, typ, err)
+			}
+			if !want && err == nil {
+				t.Fatalf("%s with local %s: expected error", name, typ)
+			}
+		}
+	}
+}
+
+func TestValidate_ExtraFields(t *testing.T) {
+	cases := []struct {
+		tracker TrackerEntry
+		want    string
+	}{
+		{TrackerEntry{Type: "github", Mode: "release", Repo: "a/b", PR: 3}, "pr not allowed for github release"},
+		{TrackerEntry{Type: "github", Mode: "release", Repo: "a/b", TokenEnv: "GH"}, "tokenEnv not allowed for github"},
+		{TrackerEntry{Type: "http", URL: "https://example.com/v", JSONPath: "version", Chart: "x"}, "chart not allowed for http"},
+		{TrackerEntry{Type: "git", Mode: "commit", URL: "https://example.com/x.git", Branch: "main", Local: LocalEntry{Type: "dpkg", Package: "x"}}, "local.type dpkg not allowed for git commit"},
+		{TrackerEntry{Type: "brew", Formula: "x", Registry: "https://example.com"}, "registry not allowed for brew"},
+		{TrackerEntry{Type: "feed", URL: "https://example.com/feed", JSONPath: "version"}, "jsonPath not allowed for feed"},
+		{TrackerEntry{Type: "pypi", Package: "x", Image: "nginx"}, "image not allowed for pypi"},
+		{TrackerEntry{Type: "eol", Product: "nodejs", Constraint: "^20"}, "constraint not allowed for eol"},
+		{TrackerEntry{Type: "github", Mode: "commit", Repo: "a/b", Branch: "main", Constraint: "^1"}, "constraint not allowed for github commit"},
+	}
+	for _, tc := range cases {
+		err := validTracker(tc.tracker).Validate()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: got %v, want %q", tc.tracker.Type, err, tc.want)
+		}
+	}
+}
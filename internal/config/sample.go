@@ -15,6 +15,8 @@ trackers:
     type: github
     mode: release
     repo: anthropics/clawdbot
+    # optional: prereleases (-rc, -beta, nightly) are skipped unless enabled
+    # includePrereleases: true
     local:
       type: command
       command: clawdbot --version
@@ -43,6 +45,10 @@ trackers:
   - name: ffmpeg
     type: brew
     formula: ffmpeg
+    # optional: only report minor/major bumps (major|minor|patch|prerelease)
+    notifyOn: minor
+    local:
+      type: brew   # installed version from brew info
 
   - name: npm-example
     label: NPM Package
@@ -1,6 +1,7 @@
 package httpx
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -13,6 +14,17 @@ type Fetcher interface {
 	Get(ctx context.Context, url string, headers map[string]string) ([]byte, error)
 }
 
+// HeaderFetcher is a Fetcher that also returns response headers, for APIs
+// that paginate with Link headers (OCI tags/list).
+type HeaderFetcher interface {
+	GetWithHeader(ctx context.Context, url string, headers map[string]string) ([]byte, http.Header, error)
+}
+
+// Poster sends POST requests for query APIs (OSV). Responses are not cached.
+type Poster interface {
+	Post(ctx context.Context, url string, body []byte, headers map[string]string) ([]byte, error)
+}
+
 type Client struct {
 	Client *http.Client
 }
@@ -24,44 +36,84 @@ func NewClient(timeout time.Duration) *Client {
 }
 
 func (c *Client) Get(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
+	body, _, err := c.GetWithHeader(ctx, url, headers)
+	return body, err
+}
+
+func (c *Client) GetWithHeader(ctx context.Context, url string, headers map[string]string) ([]byte, http.Header, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.do(req, headers)
+}
+
+func (c *Client) Post(ctx context.Context, url string, body []byte, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
+	resp, _, err := c.do(req, headers)
+	return resp, err
+}
+
+func (c *Client) do(req *http.Request, headers map[string]string) ([]byte, http.Header, error) {
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
 
 	resp, err := c.Client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("http %d", resp.StatusCode)
+		return nil, nil, &StatusError{Code: resp.StatusCode, Header: resp.Header}
 	}
-	return body, nil
+	return body, resp.Header, nil
+}
+
+// StatusError is returned for non-2xx responses. Header lets callers react to
+// e.g. WWW-Authenticate challenges.
+type StatusError struct {
+	Code   int
+	Header http.Header
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("http %d", e.Code)
 }
 
 type CachedFetcher struct {
 	Inner Fetcher
 
-	mu    sync.Mutex
-	cache map[string][]byte
+	mu     sync.Mutex
+	cache  map[string][]byte
+	header map[string]http.Header
 }
 
 func NewCachedFetcher(inner Fetcher) *CachedFetcher {
 	return &CachedFetcher{
-		Inner: inner,
-		cache: map[string][]byte{},
+		Inner:  inner,
+		cache:  map[string][]byte{},
+		header: map[string]http.Header{},
 	}
 }
 
+// Post passes through to Inner uncached; query results depend on the body.
+func (c *CachedFetcher) Post(ctx context.Context, url string, body []byte, headers map[string]string) ([]byte, error) {
+	p, ok := c.Inner.(Poster)
+	if !ok {
+		return nil, fmt.Errorf("post %s: fetcher does not support POST", url)
+	}
+	return p.Post(ctx, url, body, headers)
+}
+
 func (c *CachedFetcher) Get(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
 	c.mu.Lock()
 	if v, ok := c.cache[url]; ok {
@@ -80,3 +132,31 @@ func (c *CachedFetcher) Get(ctx context.Context, url string, headers map[string]
 	c.mu.Unlock()
 	return body, nil
 }
+
+// GetWithHeader is Get that also returns the response headers. Bodies fetched
+// through plain Get have no cached headers and are fetched again.
+func (c *CachedFetcher) GetWithHeader(ctx context.Context, url string, headers map[string]string) ([]byte, http.Header, error) {
+	c.mu.Lock()
+	if h, ok := c.header[url]; ok {
+		v := c.cache[url]
+		c.mu.Unlock()
+		return v, h, nil
+	}
+	c.mu.Unlock()
+
+	hf, ok := c.Inner.(HeaderFetcher)
+	if !ok {
+		body, err := c.Get(ctx, url, headers)
+		return body, http.Header{}, err
+	}
+	body, h, err := hf.GetWithHeader(ctx, url, headers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	c.cache[url] = body
+	c.header[url] = h
+	c.mu.Unlock()
+	return body, h, nil
+}
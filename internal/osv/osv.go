@@ -0,0 +1,214 @@
+package osv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+	"github.com/peeomid/update-tracker/internal/version"
+)
+
+type Client struct {
+	HTTP      httpx.Poster
+	UserAgent string
+	// BaseURL defaults to https://api.osv.dev.
+	BaseURL string
+}
+
+// Advisory is one vulnerability affecting the queried version.
+type Advisory struct {
+	ID       string `json:"id"`
+	Summary  string `json:"summary,omitempty"`
+	Severity string `json:"severity,omitempty"` // critical|high|moderate|low, when known
+	// FixedIn is the lowest fixed version above the queried one ("" when
+	// there is no fix yet).
+	FixedIn string `json:"fixedIn,omitempty"`
+	// FixCommit is the fixing commit for GIT lookups whose ranges only name
+	// commits, not versions.
+	FixCommit string `json:"fixCommit,omitempty"`
+	Link      string `json:"link"`
+}
+
+// Detail describes severity and fix, e.g. "high, fixed in 4.17.21".
+func (a Advisory) Detail() string {
+	var d []string
+	if a.Severity != "" {
+		d = append(d, a.Severity)
+	}
+	switch {
+	case a.FixedIn != "":
+		d = append(d, "fixed in "+a.FixedIn)
+	case a.FixCommit != "":
+		c := a.FixCommit
+		if len(c) > 12 {
+			c = c[:12]
+		}
+		d = append(d, "fixed in commit "+c)
+	default:
+		d = append(d, "no fix yet")
+	}
+	return strings.Join(d, ", ")
+}
+
+type queryReq struct {
+	Version string `json:"version"`
+	Package struct {
+		Name      string `json:"name"`
+		Ecosystem string `json:"ecosystem"`
+	} `json:"package"`
+}
+
+type vuln struct {
+	ID               string `json:"id"`
+	Summary          string `json:"summary"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+	Affected []struct {
+		Package struct {
+			Name      string `json:"name"`
+			Ecosystem string `json:"ecosystem"`
+		} `json:"package"`
+		Ranges []struct {
+			Type   string `json:"type"` // SEMVER|ECOSYSTEM|GIT
+			Repo   string `json:"repo"` // GIT only
+			Events []struct {
+				Fixed string `json:"fixed"`
+			} `json:"events"`
+		} `json:"ranges"`
+		EcosystemSpecific struct {
+			Severity string `json:"severity"`
+		} `json:"ecosystem_specific"`
+	} `json:"affected"`
+}
+
+// Query returns the advisories affecting name@ver in ecosystem (npm, PyPI,
+// crates.io, Go, Maven, RubyGems, Packagist, NuGet, or GIT with a repo URL
+// and tag).
+func (c Client) Query(ctx context.Context, ecosystem string, name string, ver string) ([]Advisory, error) {
+	base := strings.TrimRight(c.BaseURL, "/")
+	if base == "" {
+		base = "https://api.osv.dev"
+	}
+	var q queryReq
+	q.Version = ver
+	q.Package.Name = name
+	q.Package.Ecosystem = ecosystem
+	reqBody, err := json.Marshal(q)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.HTTP.Post(ctx, base+"/v1/query", reqBody, map[string]string{
+		"User-Agent":   c.UserAgent,
+		"Accept":       "application/json",
+		"Content-Type": "application/json",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query osv: %w", err)
+	}
+
+	var resp struct {
+		Vulns []vuln `json:"vulns"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse osv json: %w", err)
+	}
+	var out []Advisory
+	for _, v := range resp.Vulns {
+		out = append(out, toAdvisory(v, ecosystem, name, ver))
+	}
+	// Most severe first, then by id for stable output.
+	sort.SliceStable(out, func(i, j int) bool {
+		if ri, rj := severityRank[out[i].Severity], severityRank[out[j].Severity]; ri != rj {
+			return ri > rj
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+var severityRank = map[string]int{"low": 1, "moderate": 2, "medium": 2, "high": 3, "critical": 4}
+
+func toAdvisory(v vuln, ecosystem string, name string, ver string) Advisory {
+	a := Advisory{
+		ID:       v.ID,
+		Summary:  strings.TrimSpace(v.Summary),
+		Severity: strings.ToLower(strings.TrimSpace(v.DatabaseSpecific.Severity)),
+		Link:     "https://osv.dev/vulnerability/" + v.ID,
+	}
+	git := strings.EqualFold(ecosystem, "GIT")
+	var fixed []string
+	for _, af := range v.Affected {
+		if git {
+			// GIT entries carry no package; they are matched by range repo.
+			matched := false
+			for _, r := range af.Ranges {
+				if strings.EqualFold(r.Type, "GIT") && sameRepo(r.Repo, name) {
+					matched = true
+				}
+			}
+			if !matched {
+				continue
+			}
+		} else if !strings.EqualFold(af.Package.Ecosystem, ecosystem) || !strings.EqualFold(af.Package.Name, name) {
+			continue
+		}
+		if a.Severity == "" {
+			a.Severity = strings.ToLower(strings.TrimSpace(af.EcosystemSpecific.Severity))
+		}
+		for _, r := range af.Ranges {
+			for _, e := range r.Events {
+				if e.Fixed == "" {
+					continue
+				}
+				switch strings.ToUpper(r.Type) {
+				case "SEMVER", "ECOSYSTEM":
+					fixed = append(fixed, e.Fixed)
+				case "GIT":
+					// A commit, not a version; only used when no version fix is known.
+					if git && a.FixCommit == "" && sameRepo(r.Repo, name) {
+						a.FixCommit = e.Fixed
+					}
+				}
+			}
+		}
+	}
+	a.FixedIn = lowestFixAbove(fixed, ver)
+	if a.FixedIn != "" {
+		a.FixCommit = ""
+	}
+	return a
+}
+
+// sameRepo compares git repository URLs, ignoring case, a trailing slash and
+// a ".git" suffix.
+func sameRepo(a string, b string) bool {
+	norm := func(s string) string {
+		s = strings.TrimSuffix(strings.TrimSpace(s), "/")
+		return strings.ToLower(strings.TrimSuffix(s, ".git"))
+	}
+	return norm(a) != "" && norm(a) == norm(b)
+}
+
+// lowestFixAbove picks the lowest fixed version newer than ver; with several
+// release lines (1.x and 2.x fixes) that is the nearest upgrade.
+func lowestFixAbove(fixed []string, ver string) string {
+	best := ""
+	for _, f := range fixed {
+		// Fixes that aren't comparable versions (e.g. commit hashes) are skipped.
+		if c, ok := version.CompareStrings(f, ver); !ok || c <= 0 {
+			continue
+		}
+		if best == "" {
+			best = f
+			continue
+		}
+		if c, ok := version.CompareStrings(f, best); ok && c < 0 {
+			best = f
+		}
+	}
+	return best
+}
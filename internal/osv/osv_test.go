@@ -0,0 +1,93 @@
+package osv
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+func TestQuery(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/query" {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var q queryReq
+		if err := json.Unmarshal(body, &q); err != nil || q.Package.Name != "lodash" || q.Package.Ecosystem != "npm" || q.Version != "4.17.15" {
+			http.Error(w, "bad query "+string(body), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"vulns": [
+  {"id": "GHSA-p6mc-m468-83gw", "summary": "Prototype Pollution in lodash",
+   "database_specific": {"severity": "HIGH"},
+   "affected": [{"package": {"ecosystem": "npm", "name": "lodash"},
+     "ranges": [{"type": "SEMVER", "events": [{"introduced": "3.7.0"}, {"fixed": "4.17.19"}]}]}]},
+  {"id": "GHSA-35jh-r3h4-6jhm", "summary": "Command Injection in lodash",
+   "database_specific": {"severity": "CRITICAL"},
+   "affected": [{"package": {"ecosystem": "npm", "name": "lodash"},
+     "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]},
+     {"package": {"ecosystem": "npm", "name": "lodash.template"},
+     "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.5.0"}]}]}]},
+  {"id": "GHSA-x5rq-j2xg-h7qm", "summary": "ReDoS in lodash",
+   "affected": [{"package": {"ecosystem": "npm", "name": "lodash"},
+     "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "3.10.2"}, {"introduced": "4.0.0"}, {"fixed": "4.17.16"}]}]}]}
+]}`))
+	}))
+	defer srv.Close()
+
+	c := Client{HTTP: httpx.NewClient(5 * time.Second), BaseURL: srv.URL}
+	got, err := c.Query(context.Background(), "npm", "lodash", "4.17.15")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("advisories=%+v", got)
+	}
+	if got[0].ID != "GHSA-35jh-r3h4-6jhm" || got[0].Severity != "critical" || got[0].FixedIn != "4.17.21" {
+		t.Fatalf("first=%+v", got[0])
+	}
+	if got[1].ID != "GHSA-p6mc-m468-83gw" || got[1].FixedIn != "4.17.19" {
+		t.Fatalf("second=%+v", got[1])
+	}
+	if got[2].Severity != "" || got[2].FixedIn != "4.17.16" || got[2].Link != "https://osv.dev/vulnerability/GHSA-x5rq-j2xg-h7qm" {
+		t.Fatalf("third=%+v", got[2])
+	}
+}
+
+func TestToAdvisoryGit(t *testing.T) {
+	var v vuln
+	raw := `{"id": "CVE-2024-2398", "affected": [
+  {"ranges": [{"type": "GIT", "repo": "https://github.com/curl/curl.git",
+    "events": [{"introduced": "0"}, {"fixed": "deca8039991886a559b67bcd6701db800a5cf764"}]}]},
+  {"package": {"ecosystem": "npm", "name": "curl"},
+   "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "9.9.9"}]}]}]}`
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	a := toAdvisory(v, "GIT", "https://github.com/curl/curl", "curl-8_6_0")
+	if a.FixedIn != "" || a.FixCommit != "deca8039991886a559b67bcd6701db800a5cf764" {
+		t.Fatalf("advisory=%+v", a)
+	}
+	if got := a.Detail(); got != "fixed in commit deca80399918" {
+		t.Fatalf("detail=%q", got)
+	}
+
+	// Another repo's GIT range doesn't apply.
+	if a := toAdvisory(v, "GIT", "https://github.com/other/repo", "v1.0.0"); a.FixCommit != "" || a.Detail() != "no fix yet" {
+		t.Fatalf("other repo: %+v", a)
+	}
+}
+
+func TestLowestFixAboveSkipsCommits(t *testing.T) {
+	fixed := []string{"deca8039991886a559b67bcd6701db800a5cf764", "8.7.1", "8.6.1"}
+	if got := lowestFixAbove(fixed, "8.6.0"); got != "8.6.1" {
+		t.Fatalf("got %q", got)
+	}
+}
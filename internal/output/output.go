@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/peeomid/update-tracker/internal/app"
+	"github.com/peeomid/update-tracker/internal/trackers"
 )
 
 func Text(r app.Report) string {
@@ -20,7 +21,7 @@ func Text(r app.Report) string {
 		b.WriteString(fmt.Sprintf("[%s] %s - %s", it.Name, strings.ToUpper(it.Status), msg))
 		b.WriteString("\n")
 	}
-	b.WriteString(fmt.Sprintf("Summary: ok=%d update=%d error=%d\n", r.Summary.OK, r.Summary.Update, r.Summary.Error))
+	b.WriteString("Summary: " + summaryCounts(r.Summary) + "\n")
 	return b.String()
 }
 
@@ -55,11 +56,18 @@ func Markdown(r app.Report) string {
 	var b strings.Builder
 	b.WriteString(fmt.Sprintf("Run: `%s`\n\n", r.RunAt.Format(time.RFC3339)))
 	for _, it := range r.Items {
-		hasHighlights := strings.TrimSpace(it.Highlights) != ""
+		highlights := combinedHighlights(it)
+		hasHighlights := highlights != ""
 		msg := it.Message
 		if it.Status == "error" && strings.TrimSpace(it.Error) != "" {
 			msg = it.Error
 		}
+		if behind := behindSummary(it); behind != "" {
+			msg += " - " + behind
+		}
+		if since := skippedSummary(it); since != "" {
+			msg += " (" + since + ")"
+		}
 		b.WriteString("- `")
 		b.WriteString(it.Name)
 		b.WriteString("` **")
@@ -69,7 +77,7 @@ func Markdown(r app.Report) string {
 		if hasHighlights {
 			b.WriteString("\n")
 			b.WriteString("  - Highlights:\n")
-			for _, line := range strings.Split(it.Highlights, "\n") {
+			for _, line := range strings.Split(highlights, "\n") {
 				line = strings.TrimRight(line, " \t")
 				if line == "" {
 					continue
@@ -99,7 +107,7 @@ func Markdown(r app.Report) string {
 		}
 		b.WriteString("\n")
 	}
-	b.WriteString(fmt.Sprintf("\nSummary: ok=%d update=%d error=%d\n", r.Summary.OK, r.Summary.Update, r.Summary.Error))
+	b.WriteString("\nSummary: " + summaryCounts(r.Summary) + "\n")
 	return b.String()
 }
 
@@ -165,10 +173,20 @@ func renderDiscordItem(it app.ReportItem) string {
 
 	// Auto-detect PR mode even without explicit display field.
 	display := strings.TrimSpace(it.Display)
-	if display == "" && it.Mode == "pr" {
+	if display == "" && (it.Mode == "pr" || it.Mode == "mr") {
 		display = "pr"
 	}
 
+	// Vulnerabilities and end of life are the headline whatever the display.
+	switch it.Status {
+	case "vulnerable":
+		return renderVulnerable(it, label)
+	case "eol":
+		return fmt.Sprintf("⛔ %s", it.Message)
+	case "eol-soon":
+		return fmt.Sprintf("⏳ %s", it.Message)
+	}
+
 	switch display {
 	case "clawdbot":
 		return renderClawdbot(it, label)
@@ -200,11 +218,18 @@ func renderClawdbot(it app.ReportItem, label string) string {
 		var b strings.Builder
 		b.WriteString("🔄 **Clawdbot Update Available!**\n\n")
 		b.WriteString(fmt.Sprintf("Current: %s\n", local))
-		b.WriteString(fmt.Sprintf("Latest:  %s\n\n", latest))
-		b.WriteString("(several versions behind)")
-		if strings.TrimSpace(it.Highlights) != "" {
+		b.WriteString(fmt.Sprintf("Latest:  %s", latest))
+		if behind := behindSummary(it); behind != "" {
+			b.WriteString("\n\n")
+			b.WriteString(behind)
+		}
+		if since := skippedSummary(it); since != "" {
+			b.WriteString("\n\n")
+			b.WriteString(since)
+		}
+		if highlights := combinedHighlights(it); highlights != "" {
 			b.WriteString("\n\nHighlights:\n")
-			b.WriteString(strings.TrimSpace(it.Highlights))
+			b.WriteString(highlights)
 		}
 		if it.Links != nil && strings.TrimSpace(it.Links["release"]) != "" {
 			b.WriteString("\n\n🔗 ")
@@ -233,42 +258,46 @@ func renderCompare(it app.ReportItem, label string) string {
 		return fmt.Sprintf("%s: ⚠️ local check failed", label)
 	}
 
-	switch it.Type {
-	case "npm":
-		if local == "not-installed" {
-			return fmt.Sprintf("%s: ❌ not installed", label)
-		}
-		if local != "" && latest != "" && local == latest {
-			return fmt.Sprintf("%s: ✅ %s (up-to-date)", label, local)
+	if local == "not-installed" {
+		return fmt.Sprintf("%s: ❌ not installed", label)
+	}
+
+	if it.Type == "oci" {
+		local, latest = trackers.ShortDigest(local), trackers.ShortDigest(latest)
+	}
+	if it.Mode == "commit" {
+		ls := short7(local)
+		rs := short7(latest)
+		if ls != "" && rs != "" && (strings.HasPrefix(latest, local) || strings.HasPrefix(local, latest) || local == latest) {
+			return fmt.Sprintf("%s: ✅ %s (up-to-date)", label, ls)
 		}
-		if local != "" && latest != "" {
-			return fmt.Sprintf("%s: 🔄 %s → %s", label, local, latest)
+		if ls != "" && rs != "" {
+			return fmt.Sprintf("%s: 🔄 %s → %s", label, ls, rs)
 		}
-		if local != "" {
-			return fmt.Sprintf("%s: ⚠️ %s (latest: %s)", label, local, latest)
+		if ls != "" {
+			return fmt.Sprintf("%s: ⚠️ %s (remote: %s)", label, ls, rs)
 		}
 		return fmt.Sprintf("%s: ⚠️ unknown", label)
-	case "github":
-		if it.Mode == "commit" {
-			ls := short7(local)
-			rs := short7(latest)
-			if ls != "" && rs != "" && (strings.HasPrefix(latest, local) || strings.HasPrefix(local, latest) || local == latest) {
-				return fmt.Sprintf("%s: ✅ %s (up-to-date)", label, ls)
-			}
-			if ls != "" && rs != "" {
-				return fmt.Sprintf("%s: 🔄 %s → %s", label, ls, rs)
-			}
-			if ls != "" {
-				return fmt.Sprintf("%s: ⚠️ %s (remote: %s)", label, ls, rs)
-			}
-			return fmt.Sprintf("%s: ⚠️ unknown", label)
-		}
 	}
 
-	if local != "" && latest != "" && local == latest {
-		return fmt.Sprintf("%s: ✅ %s (up-to-date)", label, local)
-	}
 	if local != "" && latest != "" {
+		switch it.Compare {
+		case "ahead":
+			return fmt.Sprintf("%s: ✅ %s (ahead of %s)", label, local, latest)
+		case "equal":
+			return fmt.Sprintf("%s: ✅ %s (up-to-date)", label, local)
+		case "":
+			if local == latest {
+				return fmt.Sprintf("%s: ✅ %s (up-to-date)", label, local)
+			}
+		}
+		if it.Type == "brew" {
+			// brew's own wording, as in `brew outdated`.
+			local, latest = "installed "+local, "stable "+latest
+		}
+		if it.BehindCount > 0 {
+			return fmt.Sprintf("%s: 🔄 %s → %s (%s)", label, local, latest, versionsBehind(it.BehindCount))
+		}
 		return fmt.Sprintf("%s: 🔄 %s → %s", label, local, latest)
 	}
 	if local != "" {
@@ -277,6 +306,23 @@ func renderCompare(it app.ReportItem, label string) string {
 	return fmt.Sprintf("%s: ⚠️ unknown", label)
 }
 
+func renderVulnerable(it app.ReportItem, label string) string {
+	noun := "advisories"
+	if len(it.Advisories) == 1 {
+		noun = "advisory"
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("🚨 **%s** %s is vulnerable (%d %s)", label, strings.TrimSpace(it.Local), len(it.Advisories), noun))
+	for _, a := range it.Advisories {
+		b.WriteString(fmt.Sprintf("\n  - %s (%s)", a.ID, a.Detail()))
+		if a.Summary != "" {
+			b.WriteString(": " + a.Summary)
+		}
+		b.WriteString(fmt.Sprintf("\n    🔗 %s", a.Link))
+	}
+	return b.String()
+}
+
 func renderPR(it app.ReportItem, label string) string {
 	msg := it.Message
 	if it.Status == "error" && strings.TrimSpace(it.Error) != "" {
@@ -300,12 +346,82 @@ func renderPR(it app.ReportItem, label string) string {
 
 	var b strings.Builder
 	b.WriteString(fmt.Sprintf("%s **%s** — %s", emoji, label, msg))
-	if it.Links != nil && strings.TrimSpace(it.Links["pr"]) != "" {
-		b.WriteString(fmt.Sprintf("\n  🔗 %s", it.Links["pr"]))
+	link := strings.TrimSpace(it.Links["pr"])
+	if link == "" {
+		link = strings.TrimSpace(it.Links["mr"])
+	}
+	if link != "" {
+		b.WriteString(fmt.Sprintf("\n  🔗 %s", link))
 	}
 	return b.String()
 }
 
+// summaryCounts returns "ok=1 update=2 error=0"; vulnerable and eol are
+// only listed when there are such items.
+func summaryCounts(s app.Summary) string {
+	out := fmt.Sprintf("ok=%d update=%d", s.OK, s.Update)
+	if s.Vulnerable > 0 {
+		out += fmt.Sprintf(" vulnerable=%d", s.Vulnerable)
+	}
+	if s.EOL > 0 {
+		out += fmt.Sprintf(" eol=%d", s.EOL)
+	}
+	return out + fmt.Sprintf(" error=%d", s.Error)
+}
+
+// behindSummary returns e.g. "3 versions behind (1.2.0 → 1.5.0)", or "" when
+// the count is unknown.
+func behindSummary(it app.ReportItem) string {
+	if it.BehindCount <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s → %s)", versionsBehind(it.BehindCount), strings.TrimSpace(it.Local), strings.TrimSpace(it.Latest))
+}
+
+func versionsBehind(n int) string {
+	if n == 1 {
+		return "1 version behind"
+	}
+	return fmt.Sprintf("%d versions behind", n)
+}
+
+// skippedSummary returns e.g. "3 releases since 1.4.0", or "" when the tracker
+// didn't report which releases were skipped.
+func skippedSummary(it app.ReportItem) string {
+	n := len(it.Skipped)
+	if n == 0 {
+		return ""
+	}
+	noun := "releases"
+	if n == 1 {
+		noun = "release"
+	}
+	since := strings.TrimSpace(it.Prev)
+	if since == "" {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %s since %s", n, noun, since)
+}
+
+// combinedHighlights merges the highlights of every skipped release, one block
+// per tag. With a single release it is just the item's highlights.
+func combinedHighlights(it app.ReportItem) string {
+	if len(it.Skipped) > 1 {
+		var blocks []string
+		for _, r := range it.Skipped {
+			h := strings.TrimSpace(r.Highlights)
+			if h == "" {
+				continue
+			}
+			blocks = append(blocks, r.Tag+":\n"+h)
+		}
+		if len(blocks) > 0 {
+			return strings.Join(blocks, "\n")
+		}
+	}
+	return strings.TrimSpace(it.Highlights)
+}
+
 func short7(s string) string {
 	s = strings.TrimSpace(s)
 	if len(s) >= 7 {
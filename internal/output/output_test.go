@@ -2,10 +2,13 @@ package output
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/peeomid/update-tracker/internal/app"
+	"github.com/peeomid/update-tracker/internal/osv"
+	"github.com/peeomid/update-tracker/internal/trackers"
 )
 
 func TestJSONSchemaShape(t *testing.T) {
@@ -97,7 +100,7 @@ func TestMarkdown_DiscordStyle_CompareAndClawdbot(t *testing.T) {
 	}
 
 	got := Markdown(r)
-	want := "🔄 **Clawdbot Update Available!**\n\nCurrent: 2026.1.24-3\nLatest:  2026.2.2\n\n(several versions behind)\n\nHighlights:\n- A\n- B\n\n🔗 https://example.com/release\n\nNPM Package: ✅ 2026.1.24 (up-to-date)\n\nLocal Clone: ✅ 1006798 (up-to-date)\n"
+	want := "🔄 **Clawdbot Update Available!**\n\nCurrent: 2026.1.24-3\nLatest:  2026.2.2\n\nHighlights:\n- A\n- B\n\n🔗 https://example.com/release\n\nNPM Package: ✅ 2026.1.24 (up-to-date)\n\nLocal Clone: ✅ 1006798 (up-to-date)\n"
 	if got != want {
 		t.Fatalf("markdown mismatch\n--- got ---\n%s\n--- want ---\n%s", got, want)
 	}
@@ -128,3 +131,93 @@ func TestMarkdown_DiscordStyle_LobsterHeaderOnUpdate(t *testing.T) {
 		t.Fatalf("expected lobster header, got: %q", got)
 	}
 }
+
+func TestMarkdown_DiscordStyle_ClawdbotSkippedReleases(t *testing.T) {
+	r := app.Report{
+		Items: []app.ReportItem{
+			{
+				Name:        "clawdbot-release",
+				Label:       "Clawdbot",
+				Display:     "clawdbot",
+				Type:        "github",
+				Mode:        "release",
+				Status:      "update",
+				Prev:        "v1.4.0",
+				Current:     "v1.6.0",
+				Latest:      "1.6.0",
+				Local:       "1.4.0",
+				BehindCount: 3,
+				Skipped: []trackers.Release{
+					{Tag: "v1.6.0", Highlights: "- C"},
+					{Tag: "v1.5.1"},
+					{Tag: "v1.5.0", Highlights: "- A\n- B"},
+				},
+				Highlights: "- C",
+			},
+		},
+	}
+
+	got := Markdown(r)
+	want := "🔄 **Clawdbot Update Available!**\n\nCurrent: 1.4.0\nLatest:  1.6.0\n\n3 versions behind (1.4.0 → 1.6.0)\n\n3 releases since v1.4.0\n\nHighlights:\nv1.6.0:\n- C\nv1.5.0:\n- A\n- B\n"
+	if got != want {
+		t.Fatalf("markdown mismatch\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
+
+func TestEOLStatusOutput(t *testing.T) {
+	r := app.Report{
+		Summary: app.Summary{EOL: 1},
+		Items: []app.ReportItem{
+			{
+				Name:    "node",
+				Label:   "Node",
+				Display: "compare",
+				Type:    "eol",
+				Status:  "eol",
+				Local:   "18.20.4",
+				Latest:  "18.20.4",
+				Cycle:   "18",
+				EOL:     "2025-04-30",
+				Message: "Node 18 reached EOL 30 days ago (2025-04-30); latest 23.1.0 (cycle 23)",
+			},
+		},
+	}
+	if got, want := Markdown(r), "⛔ Node 18 reached EOL 30 days ago (2025-04-30); latest 23.1.0 (cycle 23)\n"; got != want {
+		t.Fatalf("markdown: got %q want %q", got, want)
+	}
+	want := "[node] EOL - Node 18 reached EOL 30 days ago (2025-04-30); latest 23.1.0 (cycle 23)\nSummary: ok=0 update=0 eol=1 error=0\n"
+	if got := Text(r); got != want {
+		t.Fatalf("text: got %q want %q", got, want)
+	}
+}
+
+func TestVulnerableOutput(t *testing.T) {
+	r := app.Report{
+		Summary: app.Summary{Vulnerable: 1},
+		Items: []app.ReportItem{
+			{
+				Name:    "lodash",
+				Type:    "npm",
+				Status:  "vulnerable",
+				Local:   "4.17.15",
+				Latest:  "4.17.21",
+				Message: "4.17.15 is affected by 2 advisories: GHSA-35jh-r3h4-6jhm (critical, fixed in 4.17.21), GHSA-29mw-wpgm-hmr9 (no fix yet); latest 4.17.21",
+				Advisories: []osv.Advisory{
+					{ID: "GHSA-35jh-r3h4-6jhm", Summary: "Command Injection in lodash", Severity: "critical", FixedIn: "4.17.21", Link: "https://osv.dev/vulnerability/GHSA-35jh-r3h4-6jhm"},
+					{ID: "GHSA-29mw-wpgm-hmr9", Link: "https://osv.dev/vulnerability/GHSA-29mw-wpgm-hmr9"},
+				},
+			},
+		},
+	}
+	want := "🚨 **lodash** 4.17.15 is vulnerable (2 advisories)\n" +
+		"  - GHSA-35jh-r3h4-6jhm (critical, fixed in 4.17.21): Command Injection in lodash\n" +
+		"    🔗 https://osv.dev/vulnerability/GHSA-35jh-r3h4-6jhm\n" +
+		"  - GHSA-29mw-wpgm-hmr9 (no fix yet)\n" +
+		"    🔗 https://osv.dev/vulnerability/GHSA-29mw-wpgm-hmr9\n"
+	if got := discordMarkdown(r); got != want {
+		t.Fatalf("markdown mismatch\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+	if got := Text(r); !strings.HasSuffix(got, "Summary: ok=0 update=0 vulnerable=1 error=0\n") || !strings.HasPrefix(got, "[lodash] VULNERABLE - 4.17.15 is affected") {
+		t.Fatalf("text: %q", got)
+	}
+}
@@ -10,40 +10,86 @@ import (
 )
 
 type brewFormula struct {
-	Exec    execx.Runner
+	Exec execx.Runner
+	// Formula is a formula or cask name, optionally tap-qualified (owner/tap/name).
 	Formula string
+	Cask    bool
+	Filter  releaseFilter
 }
 
 type brewInfoV2 struct {
-	Formulae []struct {
-		Name     string `json:"name"`
-		Homepage string `json:"homepage"`
-		Versions struct {
-			Stable string `json:"stable"`
-		} `json:"versions"`
-	} `json:"formulae"`
+	Formulae []brewFormulaInfo `json:"formulae"`
+	Casks    []brewCaskInfo    `json:"casks"`
+}
+
+type brewCaskInfo struct {
+	Token    string `json:"token"`
+	Homepage string `json:"homepage"`
+	// Version may carry a build suffix after a comma (7.1,1234).
+	Version string `json:"version"`
+}
+
+type brewFormulaInfo struct {
+	Name     string `json:"name"`
+	Homepage string `json:"homepage"`
+	Versions struct {
+		Stable string `json:"stable"`
+	} `json:"versions"`
+	VersionedFormulae []string `json:"versioned_formulae"`
 }
 
 func (b brewFormula) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
 	_ = opts
-	out, err := b.Exec.Run(ctx, "brew", "info", "--json=v2", b.Formula)
-	if err != nil {
-		return Result{}, fmt.Errorf("brew info: %w", err)
+	if b.Cask {
+		return b.checkCask(ctx, prevSeen)
 	}
-
-	var info brewInfoV2
-	if err := json.Unmarshal([]byte(out), &info); err != nil {
-		return Result{}, fmt.Errorf("brew info json: %w", err)
-	}
-	if len(info.Formulae) == 0 {
-		return Result{}, fmt.Errorf("brew info json: missing formulae")
+	f, err := b.info(ctx, b.Formula)
+	if err != nil {
+		return Result{}, err
 	}
-	f := info.Formulae[0]
 	version := strings.TrimSpace(f.Versions.Stable)
 	if version == "" {
 		return Result{}, fmt.Errorf("brew info: missing stable version")
 	}
 
+	// Versioned formulae (node@20) are the other published release lines:
+	// they can satisfy a constraint and feed the "versions behind" count.
+	needVersioned := !b.Filter.Constraint.IsZero() && !b.Filter.allows(version)
+	var candidates []string
+	byVersion := map[string]string{}
+	for _, name := range f.VersionedFormulae {
+		name = tapPrefix(b.Formula) + name
+		vf, err := b.info(ctx, name)
+		if err != nil {
+			if needVersioned {
+				return Result{}, err
+			}
+			// Only the count depends on it; don't fail the tracker.
+			candidates = nil
+			break
+		}
+		v := strings.TrimSpace(vf.Versions.Stable)
+		candidates = append(candidates, v)
+		byVersion[v] = name
+	}
+	var published []string
+	if len(candidates) > 0 {
+		for _, v := range append([]string{version}, candidates...) {
+			if b.Filter.allows(v) {
+				published = append(published, v)
+			}
+		}
+	}
+
+	formula := b.Formula
+	if needVersioned {
+		v, ok := b.Filter.newest(candidates)
+		if !ok {
+			return Result{}, fmt.Errorf("brew info: no version matches (%s)", b.Filter.describe())
+		}
+		version, formula = v, byVersion[v]
+	}
+
 	links := map[string]string{}
 	if strings.TrimSpace(f.Homepage) != "" {
 		links["homepage"] = f.Homepage
@@ -54,9 +100,87 @@ func (b brewFormula) Check(ctx context.Context, prevSeen string, opts Options) (
 	if prev != "" && prev != version {
 		msg = fmt.Sprintf("new version %s", version)
 	}
+	if formula != b.Formula {
+		msg += fmt.Sprintf(" (%s)", formula)
+	}
+	// Versions is only set with versioned formulae: brew knows just the latest
+	// of each release line, so without them every gap would count as 1.
+	return Result{
+		Current:  version,
+		Message:  msg,
+		Links:    links,
+		Versions: published,
+	}, nil
+}
+
+func (b brewFormula) checkCask(ctx context.Context, prevSeen string) (Result, error) {
+	info, err := b.infoJSON(ctx, "--cask", b.Formula)
+	if err != nil {
+		return Result{}, err
+	}
+	if len(info.Casks) == 0 {
+		return Result{}, fmt.Errorf("brew info json: missing casks")
+	}
+	c := info.Casks[0]
+	version := brewCaskVersion(c.Version)
+	if version == "" {
+		return Result{}, fmt.Errorf("brew info: missing cask version")
+	}
+	// Casks have no versioned variants to fall back to.
+	if !b.Filter.Constraint.IsZero() && !b.Filter.allows(version) {
+		return Result{}, fmt.Errorf("brew info: cask version %s does not match (%s)", version, b.Filter.describe())
+	}
+
+	links := map[string]string{}
+	if strings.TrimSpace(c.Homepage) != "" {
+		links["homepage"] = c.Homepage
+	}
+	msg := fmt.Sprintf("latest %s", version)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != version {
+		msg = fmt.Sprintf("new version %s", version)
+	}
 	return Result{
 		Current: version,
 		Message: msg,
 		Links:   links,
 	}, nil
 }
+
+// brewCaskVersion drops the build suffix from a cask version ("7.1,1234" -> "7.1").
+func brewCaskVersion(v string) string {
+	v, _, _ = strings.Cut(strings.TrimSpace(v), ",")
+	return v
+}
+
+// tapPrefix returns "owner/tap/" for a tap-qualified name, else "". brew
+// lists versioned formulae by short name, which only resolves in core.
+func tapPrefix(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i+1]
+	}
+	return ""
+}
+
+func (b brewFormula) info(ctx context.Context, formula string) (brewFormulaInfo, error) {
+	info, err := b.infoJSON(ctx, formula)
+	if err != nil {
+		return brewFormulaInfo{}, err
+	}
+	if len(info.Formulae) == 0 {
+		return brewFormulaInfo{}, fmt.Errorf("brew info json: missing formulae")
+	}
+	return info.Formulae[0], nil
+}
+
+func (b brewFormula) infoJSON(ctx context.Context, args ...string) (brewInfoV2, error) {
+	out, err := b.Exec.Run(ctx, "brew", append([]string{"info", "--json=v2"}, args...)...)
+	if err != nil {
+		return brewInfoV2{}, fmt.Errorf("brew info: %w", err)
+	}
+	var info brewInfoV2
+	if err := json.Unmarshal([]byte(out), &info); err != nil {
+		return brewInfoV2{}, fmt.Errorf("brew info json: %w", err)
+	}
+	return info, nil
+}
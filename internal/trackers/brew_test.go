@@ -0,0 +1,72 @@
+package trackers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/peeomid/update-tracker/internal/version"
+)
+
+// brewRunner answers `brew info --json=v2 [--cask] NAME` from a map keyed by
+// the joined arguments after --json=v2.
+type brewRunner map[string]string
+
+func (b brewRunner) Run(ctx context.Context, name string, args ...string) (string, error) {
+	return b[strings.Join(args[2:], " ")], nil
+}
+
+func TestBrewCask(t *testing.T) {
+	r := brewRunner{"--cask iterm2": `{"formulae": [], "casks": [{"token": "iterm2", "version": "3.5.4,20240901", "homepage": "https://iterm2.com/"}]}`}
+	res, err := brewFormula{Exec: r, Formula: "iterm2", Cask: true}.Check(context.Background(), "3.5.3", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "3.5.4" || res.Message != "new version 3.5.4" || res.Links["homepage"] != "https://iterm2.com/" {
+		t.Fatalf("res=%+v", res)
+	}
+}
+
+func TestBrewTapVersionedFormulae(t *testing.T) {
+	r := brewRunner{
+		"acme/tools/cli":   `{"formulae": [{"name": "cli", "versions": {"stable": "3.0.0"}, "versioned_formulae": ["cli@2"]}]}`,
+		"acme/tools/cli@2": `{"formulae": [{"name": "cli@2", "versions": {"stable": "2.9.1"}}]}`,
+	}
+	c, err := version.ParseConstraint("^2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := brewFormula{Exec: r, Formula: "acme/tools/cli", Filter: releaseFilter{Constraint: c}}.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "2.9.1" || res.Message != "latest stable 2.9.1 (acme/tools/cli@2)" {
+		t.Fatalf("res=%+v", res)
+	}
+}
+
+func TestBrewVersionedFormulaeAreCounted(t *testing.T) {
+	r := brewRunner{
+		"node":    `{"formulae": [{"name": "node", "versions": {"stable": "23.1.0"}, "versioned_formulae": ["node@22", "node@20", "node@18"]}]}`,
+		"node@22": `{"formulae": [{"name": "node@22", "versions": {"stable": "22.11.0"}}]}`,
+		"node@20": `{"formulae": [{"name": "node@20", "versions": {"stable": "20.18.0"}}]}`,
+		"node@18": `{"formulae": [{"name": "node@18", "versions": {"stable": "18.20.4"}}]}`,
+		"ffmpeg":  `{"formulae": [{"name": "ffmpeg", "versions": {"stable": "7.1"}}]}`,
+	}
+	res, err := brewFormula{Exec: r, Formula: "node"}.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "23.1.0" || len(res.Versions) != 4 {
+		t.Fatalf("current=%q versions=%v", res.Current, res.Versions)
+	}
+
+	// Without versioned formulae there is nothing to count.
+	res, err = brewFormula{Exec: r, Formula: "ffmpeg"}.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Versions != nil {
+		t.Fatalf("versions=%v", res.Versions)
+	}
+}
@@ -0,0 +1,85 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+type cratesPackage struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	Registry  string
+	Crate     string
+	Filter    releaseFilter
+}
+
+type cratesResp struct {
+	Crate struct {
+		Name             string `json:"name"`
+		MaxStableVersion string `json:"max_stable_version"`
+	} `json:"crate"`
+	Versions []struct {
+		Num    string `json:"num"`
+		Yanked bool   `json:"yanked"`
+	} `json:"versions"`
+}
+
+func (c cratesPackage) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	base := strings.TrimRight(c.Registry, "/")
+	if base == "" {
+		base = "https://crates.io"
+	}
+	// crates.io rejects requests without a User-Agent.
+	apiURL := fmt.Sprintf("%s/api/v1/crates/%s", base, url.PathEscape(c.Crate))
+	body, err := c.HTTP.Get(ctx, apiURL, map[string]string{
+		"User-Agent": c.UserAgent,
+		"Accept":     "application/json",
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch crates.io: %w", err)
+	}
+
+	var resp cratesResp
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return Result{}, fmt.Errorf("parse crates.io json: %w", err)
+	}
+
+	var published []string
+	for _, v := range resp.Versions {
+		if !v.Yanked && c.Filter.allows(v.Num) {
+			published = append(published, v.Num)
+		}
+	}
+	version, ok := c.Filter.newest(published)
+	if !ok {
+		// Without a version list, max_stable_version is the best we have.
+		stable := strings.TrimSpace(resp.Crate.MaxStableVersion)
+		if len(resp.Versions) > 0 || stable == "" || !c.Filter.allows(stable) {
+			return Result{}, fmt.Errorf("crates.io: no unyanked version matches (%s)", c.Filter.describe())
+		}
+		version = stable
+	}
+
+	// A private registry's web UI layout is unknown; link the API response instead.
+	link := apiURL
+	if base == "https://crates.io" {
+		link = fmt.Sprintf("https://crates.io/crates/%s", c.Crate)
+	}
+	msg := fmt.Sprintf("latest %s", version)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != version {
+		msg = fmt.Sprintf("new version %s", version)
+	}
+	return Result{
+		Current:  version,
+		Message:  msg,
+		Links:    map[string]string{"crates": link},
+		Versions: published,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCratesSkipsYanked(t *testing.T) {
+	body := `{
+  "crate": {"name": "ripgrep", "max_stable_version": "14.1.1"},
+  "versions": [
+    {"num": "15.0.0-alpha.1", "yanked": false},
+    {"num": "14.1.2", "yanked": true},
+    {"num": "14.1.1", "yanked": false},
+    {"num": "14.1.0", "yanked": false}
+  ]
+}`
+	tr := cratesPackage{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://crates.io/api/v1/crates/ripgrep": []byte(body),
+		}},
+		Crate: "ripgrep",
+	}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "14.1.1" {
+		t.Fatalf("current=%q", res.Current)
+	}
+	if res.Links["crates"] != "https://crates.io/crates/ripgrep" {
+		t.Fatalf("links=%v", res.Links)
+	}
+}
+
+func TestCratesPrivateRegistryLink(t *testing.T) {
+	body := `{"crate": {"name": "tool", "max_stable_version": "1.0.0"}, "versions": [{"num": "1.0.0", "yanked": false}]}`
+	tr := cratesPackage{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://crates.example.com/api/v1/crates/tool": []byte(body),
+		}},
+		Registry: "https://crates.example.com/",
+		Crate:    "tool",
+	}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Links["crates"] != "https://crates.example.com/api/v1/crates/tool" {
+		t.Fatalf("links=%v", res.Links)
+	}
+}
@@ -0,0 +1,100 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+// eolProduct follows a product's release cycles on endoflife.date. Current is
+// the latest release of the newest cycle; Cycles lets the runner find the
+// cycle of a local install and its EOL date.
+type eolProduct struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	Registry  string
+	Product   string
+}
+
+type eolCycleResp struct {
+	Cycle  json.RawMessage `json:"cycle"`
+	Latest json.RawMessage `json:"latest"`
+	// EOL is a date, or a bool when the date is unknown.
+	EOL json.RawMessage `json:"eol"`
+}
+
+func (e eolProduct) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	base := strings.TrimRight(e.Registry, "/")
+	if base == "" {
+		base = "https://endoflife.date"
+	}
+	apiURL := fmt.Sprintf("%s/api/%s.json", base, url.PathEscape(e.Product))
+	body, err := e.HTTP.Get(ctx, apiURL, map[string]string{
+		"User-Agent": e.UserAgent,
+		"Accept":     "application/json",
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch endoflife.date: %w", err)
+	}
+
+	var resp []eolCycleResp
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return Result{}, fmt.Errorf("parse endoflife.date json: %w", err)
+	}
+	var cycles []Cycle
+	for _, r := range resp {
+		c := Cycle{Name: rawString(r.Cycle), Latest: rawString(r.Latest)}
+		var ended bool
+		if err := json.Unmarshal(r.EOL, &ended); err == nil {
+			c.Ended = ended
+		} else {
+			c.EOL = rawString(r.EOL)
+		}
+		if c.Name != "" {
+			cycles = append(cycles, c)
+		}
+	}
+	if len(cycles) == 0 {
+		return Result{}, fmt.Errorf("endoflife.date: no cycles for %s", e.Product)
+	}
+
+	// Cycles are listed newest first.
+	newest := cycles[0]
+	current := newest.Latest
+	if current == "" {
+		current = newest.Name
+	}
+	msg := fmt.Sprintf("latest %s (cycle %s)", current, newest.Name)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != current {
+		msg = fmt.Sprintf("new version %s (cycle %s)", current, newest.Name)
+	}
+	var versions []string
+	for _, c := range cycles {
+		if c.Latest != "" {
+			versions = append(versions, c.Latest)
+		}
+	}
+	return Result{
+		Current:  current,
+		Message:  msg,
+		Links:    map[string]string{"eol": fmt.Sprintf("https://endoflife.date/%s", e.Product)},
+		Versions: versions,
+		Cycles:   cycles,
+	}, nil
+}
+
+// rawString formats a JSON string or number ("18", 3.12 is written as a
+// number by some products) without quotes.
+func rawString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s)
+	}
+	return strings.Trim(strings.TrimSpace(string(raw)), `"`)
+}
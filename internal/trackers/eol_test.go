@@ -0,0 +1,40 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEOLProductCycles(t *testing.T) {
+	body := `[
+  {"cycle": "23", "releaseDate": "2024-10-16", "eol": "2025-06-01", "latest": "23.1.0", "lts": false},
+  {"cycle": "22", "releaseDate": "2024-04-24", "eol": "2027-04-30", "latest": "22.11.0", "lts": "2024-10-29"},
+  {"cycle": "18", "releaseDate": "2022-04-19", "eol": "2025-04-30", "latest": "18.20.4", "lts": "2022-10-25"},
+  {"cycle": 0.10, "eol": true, "latest": "0.10.48"}
+]`
+	tr := eolProduct{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://endoflife.date/api/nodejs.json": []byte(body),
+		}},
+		Product: "nodejs",
+	}
+	res, err := tr.Check(context.Background(), "23.0.0", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "23.1.0" || res.Message != "new version 23.1.0 (cycle 23)" {
+		t.Fatalf("current=%q message=%q", res.Current, res.Message)
+	}
+	if len(res.Cycles) != 4 {
+		t.Fatalf("cycles=%v", res.Cycles)
+	}
+	if c := res.Cycles[2]; c.Name != "18" || c.EOL != "2025-04-30" || c.Latest != "18.20.4" {
+		t.Fatalf("cycle 18=%+v", c)
+	}
+	if c := res.Cycles[3]; c.Name != "0.10" || !c.Ended || c.EOL != "" {
+		t.Fatalf("cycle 0.10=%+v", c)
+	}
+	if res.Links["eol"] != "https://endoflife.date/nodejs" {
+		t.Fatalf("links=%v", res.Links)
+	}
+}
@@ -0,0 +1,232 @@
+package trackers
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID      string `xml:"id"`
+	Title   string `xml:"title"`
+	Updated string `xml:"updated"`
+	Content struct {
+		Type string `xml:"type,attr"`
+		Body string `xml:",chardata"`
+	} `xml:"content"`
+	Summary string `xml:"summary"`
+	Links   []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+}
+
+type rssFeed struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	GUID        string `xml:"guid"`
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	PubDate     string `xml:"pubDate"`
+	Description string `xml:"description"`
+	// content:encoded; encoding/xml matches on the local name.
+	Encoded string `xml:"encoded"`
+}
+
+// feedItem is an RSS item or Atom entry, newest first as the feed lists them.
+type feedItem struct {
+	ID      string
+	Title   string
+	Link    string
+	Date    string
+	Content string // HTML
+}
+
+// key is what the state remembers for an item: its guid/id, else link, else title.
+func (it feedItem) key() string {
+	for _, s := range []string{it.ID, it.Link, it.Title} {
+		if s = strings.TrimSpace(s); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// parseFeed reads an RSS 2.0 or Atom document.
+func parseFeed(body []byte) ([]feedItem, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.NewDecoder(bytes.NewReader(body)).Decode(&root); err != nil {
+		return nil, err
+	}
+
+	var items []feedItem
+	switch root.XMLName.Local {
+	case "feed":
+		var feed atomFeed
+		if err := xml.Unmarshal(body, &feed); err != nil {
+			return nil, err
+		}
+		for _, e := range feed.Entries {
+			content := e.Content.Body
+			if strings.TrimSpace(content) == "" {
+				content = e.Summary
+			}
+			items = append(items, feedItem{
+				ID:      strings.TrimSpace(e.ID),
+				Title:   strings.TrimSpace(e.Title),
+				Link:    entryLink(e),
+				Date:    strings.TrimSpace(e.Updated),
+				Content: content,
+			})
+		}
+	case "rss":
+		var feed rssFeed
+		if err := xml.Unmarshal(body, &feed); err != nil {
+			return nil, err
+		}
+		for _, it := range feed.Channel.Items {
+			content := it.Encoded
+			if strings.TrimSpace(content) == "" {
+				content = it.Description
+			}
+			items = append(items, feedItem{
+				ID:      strings.TrimSpace(it.GUID),
+				Title:   strings.TrimSpace(it.Title),
+				Link:    strings.TrimSpace(it.Link),
+				Date:    strings.TrimSpace(it.PubDate),
+				Content: content,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("not an rss or atom feed (root <%s>)", root.XMLName.Local)
+	}
+	return items, nil
+}
+
+// feedTracker follows any RSS/Atom feed. With Pattern, only items whose title
+// matches count, and the "version" group (or first group) becomes Current.
+type feedTracker struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	URL       string
+	Pattern   *regexp.Regexp
+	Filter    releaseFilter
+}
+
+type matchedItem struct {
+	Item feedItem
+	Seen string
+}
+
+func (f feedTracker) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	body, err := f.HTTP.Get(ctx, f.URL, map[string]string{
+		"User-Agent": f.UserAgent,
+		"Accept":     "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8",
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch feed: %w", err)
+	}
+	all, err := parseFeed(body)
+	if err != nil {
+		return Result{}, fmt.Errorf("parse feed: %w", err)
+	}
+	if len(all) == 0 {
+		return Result{}, fmt.Errorf("feed has no items")
+	}
+
+	var items []matchedItem
+	var versions []string
+	for _, it := range all {
+		if f.Pattern == nil {
+			items = append(items, matchedItem{Item: it, Seen: it.key()})
+			continue
+		}
+		v, ok := f.titleVersion(it.Title)
+		if !ok {
+			continue
+		}
+		if _, ok := f.Filter.pass(v, f.Filter.guessPrerelease(v)); !ok {
+			continue
+		}
+		items = append(items, matchedItem{Item: it, Seen: v})
+		versions = append(versions, v)
+	}
+	if len(items) == 0 {
+		return Result{}, fmt.Errorf("no feed item title matches pattern %s", f.Pattern)
+	}
+	latest := items[0]
+	if latest.Seen == "" {
+		return Result{}, fmt.Errorf("feed: newest item has no guid, link or title")
+	}
+
+	links := map[string]string{"feed": f.URL}
+	if latest.Item.Link != "" {
+		links["item"] = latest.Item.Link
+	}
+
+	title := latest.Item.Title
+	if title == "" {
+		title = latest.Seen
+	}
+	msg := fmt.Sprintf("latest: %s", title)
+	prev := strings.TrimSpace(prevSeen)
+	highlights := ""
+	var skipped []Release
+	if prev != "" && prev != latest.Seen {
+		msg = fmt.Sprintf("new: %s", title)
+		if opts.IncludeNotes {
+			highlights = extractHighlightsFromHTML(latest.Item.Content)
+		}
+		skipped = itemsSince(items, prev, opts)
+	}
+	return Result{
+		Current:    latest.Seen,
+		Message:    msg,
+		Links:      links,
+		Highlights: highlights,
+		Skipped:    skipped,
+		Versions:   versions,
+	}, nil
+}
+
+func (f feedTracker) titleVersion(title string) (string, bool) {
+	m := f.Pattern.FindStringSubmatch(title)
+	if m == nil {
+		return "", false
+	}
+	return strings.TrimSpace(submatchValue(f.Pattern, m)), true
+}
+
+// itemsSince is releasesSince for feed items.
+func itemsSince(items []matchedItem, prevSeen string, opts Options) []Release {
+	var out []Release
+	for _, it := range items {
+		if it.Seen == prevSeen {
+			return out
+		}
+		rel := Release{Tag: it.Item.Title, Date: it.Item.Date, Link: it.Item.Link}
+		if rel.Tag == "" {
+			rel.Tag = it.Seen
+		}
+		if opts.IncludeNotes {
+			rel.Highlights = extractHighlightsFromHTML(it.Item.Content)
+		}
+		out = append(out, rel)
+	}
+	return nil
+}
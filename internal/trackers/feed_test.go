@@ -0,0 +1,86 @@
+package trackers
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+const testRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>Vendor changelog</title>
+    <item>
+      <title>Tool 3.2.0 released</title>
+      <guid isPermaLink="false">post-320</guid>
+      <link>https://vendor.example.com/blog/tool-3-2-0</link>
+      <pubDate>Mon, 05 Oct 2026 09:00:00 GMT</pubDate>
+      <content:encoded><![CDATA[<ul><li>New <b>sync</b> engine</li></ul>]]></content:encoded>
+    </item>
+    <item>
+      <title>Webinar: what's next</title>
+      <guid>post-webinar</guid>
+    </item>
+    <item>
+      <title>Tool 3.1.4 released</title>
+      <guid>post-314</guid>
+      <description>&lt;ul&gt;&lt;li&gt;Fix crash&lt;/li&gt;&lt;/ul&gt;</description>
+    </item>
+    <item>
+      <title>Tool 3.1.3 released</title>
+      <guid>post-313</guid>
+    </item>
+  </channel>
+</rss>`
+
+func TestFeedRSSItems(t *testing.T) {
+	tr := feedTracker{HTTP: fakeFetcher{Body: []byte(testRSS)}, URL: "https://vendor.example.com/feed.xml"}
+	res, err := tr.Check(context.Background(), "post-314", Options{IncludeNotes: true})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "post-320" || res.Message != "new: Tool 3.2.0 released" {
+		t.Fatalf("current=%q message=%q", res.Current, res.Message)
+	}
+	if res.Highlights != "- New sync engine" {
+		t.Fatalf("highlights=%q", res.Highlights)
+	}
+	if len(res.Skipped) != 2 || res.Links["item"] != "https://vendor.example.com/blog/tool-3-2-0" {
+		t.Fatalf("skipped=%+v links=%v", res.Skipped, res.Links)
+	}
+}
+
+func TestFeedTitlePattern(t *testing.T) {
+	tr := feedTracker{
+		HTTP:    fakeFetcher{Body: []byte(testRSS)},
+		URL:     "https://vendor.example.com/feed.xml",
+		Pattern: regexp.MustCompile(`^Tool (?P<version>\S+) released$`),
+	}
+	res, err := tr.Check(context.Background(), "3.1.3", Options{IncludeNotes: true})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "3.2.0" || len(res.Versions) != 3 {
+		t.Fatalf("current=%q versions=%v", res.Current, res.Versions)
+	}
+	if len(res.Skipped) != 2 || res.Skipped[1].Highlights != "- Fix crash" {
+		t.Fatalf("skipped=%+v", res.Skipped)
+	}
+}
+
+func TestParseFeedAtom(t *testing.T) {
+	atom := `<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry><id>urn:1</id><title>Advisory 1</title><updated>2026-10-01T00:00:00Z</updated>
+    <link rel="alternate" href="https://sec.example.com/1"/><summary>short</summary></entry>
+</feed>`
+	items, err := parseFeed([]byte(atom))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(items) != 1 || items[0].key() != "urn:1" || items[0].Link != "https://sec.example.com/1" || items[0].Content != "short" {
+		t.Fatalf("items=%+v", items)
+	}
+	if _, err := parseFeed([]byte(`<html><body/></html>`)); err == nil {
+		t.Fatalf("expected error for html")
+	}
+}
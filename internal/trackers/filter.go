@@ -0,0 +1,138 @@
+package trackers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/version"
+)
+
+// releaseFilter decides which remote versions a tracker may report.
+type releaseFilter struct {
+	Constraint         version.Constraint
+	IncludePrereleases bool
+	// TagPattern selects tags; its "version" group (or first group) is the version.
+	TagPattern *regexp.Regexp
+	TagExclude *regexp.Regexp
+}
+
+var prereleaseWordRe = regexp.MustCompile(`(?i)(^|[^a-z])(alpha|beta|rc|pre|preview|nightly|canary|dev|snapshot|insiders|next|edge)([^a-z]|$)`)
+
+// allows reports whether the tag or version string passes the filter,
+// guessing prerelease status from the string itself.
+func (f releaseFilter) allows(tag string) bool {
+	_, ok := f.pass(tag, f.guessPrerelease(tag))
+	return ok
+}
+
+// guessPrerelease looks at the version part of tag (after tagPattern), so a
+// pattern like ^(\d+\.\d+)-alpine$ doesn't make "alpine" count as a prerelease.
+func (f releaseFilter) guessPrerelease(tag string) bool {
+	if v, ok := f.matchTag(tag); ok {
+		return looksPrerelease(v)
+	}
+	return looksPrerelease(tag)
+}
+
+// pass applies the filter with a known prerelease flag (e.g. from an API) and
+// returns the version part of tag.
+func (f releaseFilter) pass(tag string, prerelease bool) (string, bool) {
+	v, ok := f.matchTag(tag)
+	if !ok {
+		return "", false
+	}
+	if prerelease && !f.IncludePrereleases {
+		return "", false
+	}
+	if f.Constraint.IsZero() {
+		return v, true
+	}
+	parsed, ok := version.Extract(v)
+	if !ok {
+		return "", false
+	}
+	if f.IncludePrereleases {
+		// Opting in means "prereleases of versions in my line" too.
+		parsed.Pre = nil
+	}
+	return v, f.Constraint.Check(parsed)
+}
+
+// matchTag applies tagPattern/tagExclude and extracts the version from tag.
+func (f releaseFilter) matchTag(tag string) (string, bool) {
+	tag = strings.TrimSpace(tag)
+	if f.TagExclude != nil && f.TagExclude.MatchString(tag) {
+		return "", false
+	}
+	if f.TagPattern == nil {
+		return tag, true
+	}
+	m := f.TagPattern.FindStringSubmatch(tag)
+	if m == nil {
+		return "", false
+	}
+	if i := f.TagPattern.SubexpIndex("version"); i > 0 && m[i] != "" {
+		return m[i], true
+	}
+	if len(m) > 1 && m[1] != "" {
+		return m[1], true
+	}
+	return tag, true
+}
+
+// newest returns the highest version in vs that passes the filter.
+func (f releaseFilter) newest(vs []string) (string, bool) {
+	best := ""
+	var bestV version.Version
+	for _, s := range vs {
+		m, ok := f.pass(s, f.guessPrerelease(s))
+		if !ok {
+			continue
+		}
+		v, ok := version.Extract(m)
+		if !ok {
+			continue
+		}
+		if best == "" || version.Compare(v, bestV) > 0 {
+			best, bestV = s, v
+		}
+	}
+	return best, best != ""
+}
+
+// describe summarizes the active filters for error messages.
+func (f releaseFilter) describe() string {
+	var parts []string
+	if !f.Constraint.IsZero() {
+		parts = append(parts, "constraint "+f.Constraint.String())
+	}
+	if f.TagPattern != nil {
+		parts = append(parts, "tagPattern "+f.TagPattern.String())
+	}
+	if f.TagExclude != nil {
+		parts = append(parts, "tagExclude "+f.TagExclude.String())
+	}
+	if !f.IncludePrereleases {
+		parts = append(parts, "prereleases excluded")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// looksPrerelease reports whether a tag or version looks like a prerelease:
+// an alphabetic semver prerelease identifier (1.2.0-rc.1, 1.0b2) or a word
+// like nightly/preview/canary. Numeric suffixes (2026.1.24-3) are not prereleases.
+func looksPrerelease(s string) bool {
+	if prereleaseWordRe.MatchString(s) {
+		return true
+	}
+	v, ok := version.Extract(s)
+	if !ok {
+		return false
+	}
+	for _, id := range v.Pre {
+		if id[0] < '0' || id[0] > '9' {
+			return true
+		}
+	}
+	return false
+}
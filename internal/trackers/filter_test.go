@@ -0,0 +1,21 @@
+package trackers
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestReleaseFilterGuessesPrereleaseFromVersion(t *testing.T) {
+	f := releaseFilter{TagPattern: regexp.MustCompile(`^(?:next|edge)-v(?P<version>.+)$`)}
+
+	if !f.allows("next-v1.2.0") {
+		t.Fatalf("next-v1.2.0 should pass: the extracted version is stable")
+	}
+	if f.allows("next-v1.3.0-rc.1") {
+		t.Fatalf("next-v1.3.0-rc.1 should be skipped as a prerelease")
+	}
+	got, ok := f.newest([]string{"edge-v1.1.0", "next-v1.3.0-rc.1", "next-v1.2.0"})
+	if !ok || got != "next-v1.2.0" {
+		t.Fatalf("newest=%q ok=%v", got, ok)
+	}
+}
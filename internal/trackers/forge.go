@@ -0,0 +1,135 @@
+package trackers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// forgeRelease is a release from a forge releases API (GitLab, Gitea),
+// normalized so the trackers share filtering and reporting.
+type forgeRelease struct {
+	Tag        string
+	Notes      string // markdown
+	Date       string
+	Link       string
+	Prerelease bool
+	// Unpublished releases (drafts, upcoming) never match.
+	Unpublished bool
+}
+
+// forgeReleaseCheck reports the newest release in all (newest first) that
+// passes filter. With no releases at all it reports fallback instead.
+func forgeReleaseCheck(ctx context.Context, all []forgeRelease, filter releaseFilter, fallback Tracker, repoURL string, prevSeen string, opts Options) (Result, error) {
+	if len(all) == 0 {
+		fb, err := fallback.Check(ctx, prevSeen, opts)
+		if err != nil {
+			return Result{}, fmt.Errorf("no releases; fallback commit failed: %w", err)
+		}
+		fb.Message = "no releases; " + fb.Message
+		return fb, nil
+	}
+
+	var releases []forgeRelease
+	var versions []string
+	for _, r := range all {
+		if r.Unpublished {
+			continue
+		}
+		if v, ok := filter.pass(r.Tag, r.Prerelease); ok {
+			releases = append(releases, r)
+			versions = append(versions, v)
+		}
+	}
+	if len(releases) == 0 {
+		return Result{}, fmt.Errorf("no release matches (%s)", filter.describe())
+	}
+	latest := releases[0]
+	tag := strings.TrimSpace(latest.Tag)
+
+	links := map[string]string{"repo": repoURL}
+	if latest.Link != "" {
+		links["release"] = latest.Link
+	}
+
+	msg := fmt.Sprintf("latest release %s", tag)
+	prev := strings.TrimSpace(prevSeen)
+	highlights := ""
+	var skipped []Release
+	if prev != "" && prev != tag {
+		msg = fmt.Sprintf("new release %s", tag)
+		if opts.IncludeNotes {
+			highlights = extractHighlightsFromMarkdown(latest.Notes)
+		}
+		skipped = forgeReleasesSince(releases, prev, opts)
+	}
+	res := Result{
+		Current:    tag,
+		Message:    msg,
+		Links:      links,
+		Highlights: highlights,
+		Skipped:    skipped,
+		Versions:   versions,
+	}
+	if filter.TagPattern != nil {
+		res.Version = versions[0]
+	}
+	return res, nil
+}
+
+// forgeReleasesSince is releasesSince for forge releases APIs.
+func forgeReleasesSince(releases []forgeRelease, prevSeen string, opts Options) []Release {
+	var out []Release
+	for _, r := range releases {
+		if r.Tag == prevSeen {
+			return out
+		}
+		rel := Release{
+			Tag:  r.Tag,
+			Date: strings.TrimSpace(r.Date),
+			Link: r.Link,
+		}
+		if opts.IncludeNotes {
+			rel.Highlights = extractHighlightsFromMarkdown(r.Notes)
+		}
+		out = append(out, rel)
+	}
+	return nil
+}
+
+// forgeLink returns link, or repoURL+path when the API didn't provide one.
+func forgeLink(link string, repoURL string, path string) string {
+	if l := strings.TrimSpace(link); l != "" {
+		return l
+	}
+	return repoURL + path
+}
+
+// forgeBranchResp is the branch endpoint response of GitLab and Gitea.
+type forgeBranchResp struct {
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+// forgeCommitResult reports the head commit of branch. comparePath is the
+// forge's compare route under repoURL ("/-/compare" on GitLab).
+func forgeCommitResult(br forgeBranchResp, branch string, repoURL string, comparePath string, prevSeen string) (Result, error) {
+	sha := strings.TrimSpace(br.Commit.ID)
+	if sha == "" {
+		return Result{}, fmt.Errorf("branch %s: missing commit id", branch)
+	}
+
+	links := map[string]string{"repo": repoURL}
+	prev := strings.TrimSpace(prevSeen)
+	msg := fmt.Sprintf("latest commit on %s (%s)", branch, shortSHA(sha))
+	if prev != "" && prev != sha {
+		links["compare"] = fmt.Sprintf("%s%s/%s...%s", repoURL, comparePath, prev, sha)
+		msg = fmt.Sprintf("new commits on %s (%s -> %s)", branch, shortSHA(prev), shortSHA(sha))
+	}
+	return Result{
+		Current: sha,
+		Message: msg,
+		Links:   links,
+	}, nil
+}
@@ -0,0 +1,123 @@
+package trackers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/execx"
+)
+
+// gitBranch follows a branch head on any git remote (https, ssh, file://).
+type gitBranch struct {
+	Exec   execx.Runner
+	URL    string
+	Branch string
+}
+
+func (g gitBranch) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	ref := fmt.Sprintf("refs/heads/%s", g.Branch)
+	out, err := g.Exec.Run(ctx, "git", "ls-remote", g.URL, ref)
+	if err != nil {
+		return Result{}, fmt.Errorf("git ls-remote: %w", err)
+	}
+	fields := strings.Fields(out)
+	if len(fields) < 1 {
+		return Result{}, fmt.Errorf("git ls-remote: branch %s not found", g.Branch)
+	}
+	sha := fields[0]
+
+	msg := fmt.Sprintf("latest commit on %s (%s)", g.Branch, shortSHA(sha))
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != sha {
+		msg = fmt.Sprintf("new commits on %s (%s -> %s)", g.Branch, shortSHA(prev), shortSHA(sha))
+	}
+	return Result{
+		Current: sha,
+		Message: msg,
+		Links:   gitRemoteLinks(g.URL),
+	}, nil
+}
+
+// gitTag follows the newest tag (by version) on any git remote.
+type gitTag struct {
+	Exec   execx.Runner
+	URL    string
+	Filter releaseFilter
+}
+
+func (g gitTag) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	out, err := g.Exec.Run(ctx, "git", "ls-remote", "--tags", "--refs", g.URL)
+	if err != nil {
+		return Result{}, fmt.Errorf("git ls-remote: %w", err)
+	}
+	tags := parseLsRemoteTags(out)
+	if len(tags) == 0 {
+		return Result{}, fmt.Errorf("git ls-remote: no tags")
+	}
+	if g.Filter.TagPattern == nil {
+		// Namespaced tags (cli/v2.0.0) belong to one part of a monorepo; they
+		// are only followed when tagPattern selects them.
+		var plain []string
+		for _, t := range tags {
+			if !strings.Contains(t, "/") {
+				plain = append(plain, t)
+			}
+		}
+		tags = plain
+	}
+
+	var versions []string
+	for _, t := range tags {
+		if v, ok := g.Filter.pass(t, g.Filter.guessPrerelease(t)); ok {
+			versions = append(versions, v)
+		}
+	}
+	tag, ok := g.Filter.newest(tags)
+	if !ok {
+		return Result{}, fmt.Errorf("no version tag matches (%s)", g.Filter.describe())
+	}
+	v, _ := g.Filter.matchTag(tag)
+
+	msg := fmt.Sprintf("latest tag %s", tag)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != tag {
+		msg = fmt.Sprintf("new tag %s", tag)
+	}
+	return Result{
+		Current:  tag,
+		Version:  v,
+		Message:  msg,
+		Links:    gitRemoteLinks(g.URL),
+		Versions: versions,
+	}, nil
+}
+
+// parseLsRemoteTags returns the tag names from `git ls-remote --tags` output,
+// skipping peeled (^{}) entries.
+func parseLsRemoteTags(out string) []string {
+	var tags []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		name, ok := strings.CutPrefix(fields[1], "refs/tags/")
+		if !ok || strings.HasSuffix(name, "^{}") {
+			continue
+		}
+		tags = append(tags, name)
+	}
+	return tags
+}
+
+// gitRemoteLinks links web-browsable (http/https) remotes; ssh and file
+// remotes have no useful link.
+func gitRemoteLinks(remote string) map[string]string {
+	if !strings.HasPrefix(remote, "https://") && !strings.HasPrefix(remote, "http://") {
+		return nil
+	}
+	return map[string]string{"repo": strings.TrimSuffix(remote, ".git")}
+}
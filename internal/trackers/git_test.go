@@ -0,0 +1,61 @@
+package trackers
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/peeomid/update-tracker/internal/config"
+)
+
+func TestGitTagNewestBySemver(t *testing.T) {
+	out := "aaa\trefs/tags/v1.9.0\n" +
+		"bbb\trefs/tags/v1.10.0\n" +
+		"ccc\trefs/tags/v1.11.0-rc.1\n" +
+		"ddd\trefs/tags/cli/v2.0.0\n" +
+		"eee\trefs/tags/v1.10.0^{}\n"
+	tr := gitTag{Exec: fakeRunner{Out: out}, URL: "ssh://git@git.example.com/team/app.git"}
+
+	res, err := tr.Check(context.Background(), "v1.9.0", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	// Namespaced monorepo tags need a tagPattern.
+	if res.Current != "v1.10.0" {
+		t.Fatalf("current=%q", res.Current)
+	}
+	if res.Links != nil {
+		t.Fatalf("ssh remote should have no links: %v", res.Links)
+	}
+
+	tr.Filter = releaseFilter{TagPattern: regexp.MustCompile(`^v(?P<version>\d+\.\d+\.\d+.*)$`)}
+	res, err = tr.Check(context.Background(), "v1.9.0", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "v1.10.0" || res.Version != "1.10.0" || res.Message != "new tag v1.10.0" {
+		t.Fatalf("current=%q version=%q message=%q", res.Current, res.Version, res.Message)
+	}
+	if len(res.Versions) != 2 {
+		t.Fatalf("versions=%v", res.Versions)
+	}
+
+	tr.Filter = releaseFilter{TagPattern: regexp.MustCompile(`^cli/v(?P<version>.+)$`)}
+	res, err = tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "cli/v2.0.0" || res.Version != "2.0.0" {
+		t.Fatalf("current=%q version=%q", res.Current, res.Version)
+	}
+}
+
+func TestTagVersion(t *testing.T) {
+	cfg := config.TrackerEntry{Type: "git", Mode: "tag", TagPattern: `^cli/v(?P<version>.+)$`}
+	if got := TagVersion(cfg, "cli/v1.4.0"); got != "1.4.0" {
+		t.Fatalf("got %q", got)
+	}
+	if got := TagVersion(config.TrackerEntry{}, "v1.4.0"); got != "v1.4.0" {
+		t.Fatalf("got %q", got)
+	}
+}
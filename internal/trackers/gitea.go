@@ -0,0 +1,189 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+// giteaAPI is shared by the gitea trackers (Gitea, Forgejo, Codeberg): base
+// URL, owner/repo and optional token.
+type giteaAPI struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	BaseURL   string
+	Token     string
+	Repo      string
+}
+
+func (g giteaAPI) base() string {
+	base := strings.TrimRight(strings.TrimSpace(g.BaseURL), "/")
+	if base == "" {
+		base = "https://codeberg.org"
+	}
+	return base
+}
+
+func (g giteaAPI) webURL() string {
+	return fmt.Sprintf("%s/%s", g.base(), g.Repo)
+}
+
+func (g giteaAPI) get(ctx context.Context, path string, out any) error {
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s%s", g.base(), g.Repo, path)
+	headers := map[string]string{
+		"User-Agent": g.UserAgent,
+		"Accept":     "application/json",
+	}
+	if strings.TrimSpace(g.Token) != "" {
+		headers["Authorization"] = "token " + g.Token
+	}
+	body, err := g.HTTP.Get(ctx, apiURL, headers)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+type giteaReleaseResp struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Body        string `json:"body"`
+	Draft       bool   `json:"draft"`
+	Prerelease  bool   `json:"prerelease"`
+	HTMLURL     string `json:"html_url"`
+	PublishedAt string `json:"published_at"`
+}
+
+type giteaRelease struct {
+	API      giteaAPI
+	Filter   releaseFilter
+	Fallback giteaCommit
+}
+
+func (g giteaRelease) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	// The list (rather than /releases/latest) carries the prerelease flag and
+	// lets constraints and skipped releases work like for github.
+	var all []giteaReleaseResp
+	if err := g.API.get(ctx, "/releases?draft=false&limit=50", &all); err != nil {
+		return Result{}, fmt.Errorf("fetch releases: %w", err)
+	}
+	repoURL := g.API.webURL()
+
+	var releases []forgeRelease
+	for _, r := range all {
+		releases = append(releases, forgeRelease{
+			Tag:         r.TagName,
+			Notes:       r.Body,
+			Date:        r.PublishedAt,
+			Link:        forgeLink(r.HTMLURL, repoURL, "/releases/tag/"+url.PathEscape(r.TagName)),
+			Prerelease:  r.Prerelease,
+			Unpublished: r.Draft,
+		})
+	}
+	return forgeReleaseCheck(ctx, releases, g.Filter, g.Fallback, repoURL, prevSeen, opts)
+}
+
+type giteaCommit struct {
+	API    giteaAPI
+	Branch string
+}
+
+func (g giteaCommit) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	var br forgeBranchResp
+	if err := g.API.get(ctx, "/branches/"+url.PathEscape(g.Branch), &br); err != nil {
+		return Result{}, fmt.Errorf("fetch branch %s: %w", g.Branch, err)
+	}
+	return forgeCommitResult(br, g.Branch, g.API.webURL(), "/compare", prevSeen)
+}
+
+type giteaPR struct {
+	API giteaAPI
+	PR  int
+}
+
+type giteaPRResp struct {
+	Number  int    `json:"number"`
+	State   string `json:"state"` // open|closed
+	Draft   bool   `json:"draft"`
+	Merged  bool   `json:"merged"`
+	HTMLURL string `json:"html_url"`
+	Head    struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+func (g giteaPR) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	var pr giteaPRResp
+	if err := g.API.get(ctx, "/pulls/"+strconv.Itoa(g.PR), &pr); err != nil {
+		return Result{}, fmt.Errorf("fetch pr: %w", err)
+	}
+	if pr.Number == 0 {
+		pr.Number = g.PR
+	}
+
+	state := strings.ToLower(strings.TrimSpace(pr.State))
+	if pr.Merged {
+		state = "merged"
+	}
+	if state == "" {
+		state = "unknown"
+	}
+	checks := g.checksSummary(ctx, pr.Head.SHA)
+	currentSeen := fmt.Sprintf("%s|draft=%t|checks=%s", state, pr.Draft, checks)
+
+	repoURL := g.API.webURL()
+	prURL := strings.TrimSpace(pr.HTMLURL)
+	if prURL == "" {
+		prURL = repoURL + "/pulls/" + strconv.Itoa(pr.Number)
+	}
+
+	msg := fmt.Sprintf("PR #%d %s, checks=%s", pr.Number, state, checks)
+	if pr.Draft {
+		msg = fmt.Sprintf("PR #%d %s (draft), checks=%s", pr.Number, state, checks)
+	}
+	return Result{
+		Current: currentSeen,
+		Message: msg,
+		Links: map[string]string{
+			"repo": repoURL,
+			"pr":   prURL,
+		},
+	}, nil
+}
+
+// checksSummary reads the combined commit status (Gitea/Forgejo Actions and
+// external CI both report there).
+func (g giteaPR) checksSummary(ctx context.Context, sha string) string {
+	sha = strings.TrimSpace(sha)
+	if sha == "" {
+		return "none"
+	}
+	var st struct {
+		State      string `json:"state"`
+		TotalCount int    `json:"total_count"`
+	}
+	if err := g.API.get(ctx, "/commits/"+sha+"/status", &st); err != nil {
+		// Don't fail the whole tracker because the status endpoint failed.
+		return "unknown"
+	}
+	if st.TotalCount == 0 {
+		return "none"
+	}
+	switch strings.ToLower(strings.TrimSpace(st.State)) {
+	case "success", "warning":
+		return "success"
+	case "failure", "error":
+		return "failure"
+	case "pending":
+		return "pending"
+	default:
+		return "none"
+	}
+}
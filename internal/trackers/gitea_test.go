@@ -0,0 +1,51 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGiteaReleaseUsesPrereleaseFlag(t *testing.T) {
+	releases := `[
+  {"tag_name": "v10.0.0", "prerelease": true, "html_url": "https://codeberg.org/forgejo/forgejo/releases/tag/v10.0.0"},
+  {"tag_name": "v9.1.0", "prerelease": false, "html_url": "https://codeberg.org/forgejo/forgejo/releases/tag/v9.1.0"},
+  {"tag_name": "v9.0.3", "prerelease": false}
+]`
+	f := mapFetcher{ByURL: map[string][]byte{
+		"https://codeberg.org/api/v1/repos/forgejo/forgejo/releases?draft=false&limit=50": []byte(releases),
+	}}
+	tr := giteaRelease{API: giteaAPI{HTTP: f, Repo: "forgejo/forgejo"}}
+
+	res, err := tr.Check(context.Background(), "v9.0.3", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "v9.1.0" || res.Message != "new release v9.1.0" {
+		t.Fatalf("current=%q message=%q", res.Current, res.Message)
+	}
+	if res.Links["release"] != "https://codeberg.org/forgejo/forgejo/releases/tag/v9.1.0" {
+		t.Fatalf("links=%v", res.Links)
+	}
+	if len(res.Skipped) != 1 {
+		t.Fatalf("skipped=%+v", res.Skipped)
+	}
+}
+
+func TestGiteaPRChecks(t *testing.T) {
+	f := mapFetcher{ByURL: map[string][]byte{
+		"https://git.example.com/api/v1/repos/a/b/pulls/3":            []byte(`{"number": 3, "state": "closed", "merged": true, "head": {"sha": "abc"}}`),
+		"https://git.example.com/api/v1/repos/a/b/commits/abc/status": []byte(`{"state": "failure", "total_count": 2}`),
+	}}
+	tr := giteaPR{API: giteaAPI{HTTP: f, BaseURL: "https://git.example.com", Repo: "a/b"}, PR: 3}
+
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "merged|draft=false|checks=failure" {
+		t.Fatalf("current=%q", res.Current)
+	}
+	if res.Links["pr"] != "https://git.example.com/a/b/pulls/3" {
+		t.Fatalf("links=%v", res.Links)
+	}
+}
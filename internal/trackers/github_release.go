@@ -2,6 +2,7 @@ package trackers
 
 import (
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"strings"
@@ -14,27 +15,12 @@ type githubReleaseOrCommit struct {
 	HTTP      httpx.Fetcher
 	Exec      execx.Runner
 	UserAgent string
+	Token     string
 	Repo      string
+	Filter    releaseFilter
 	Fallback  githubCommit
 }
 
-type atomFeed struct {
-	Entries []atomEntry `xml:"entry"`
-}
-
-type atomEntry struct {
-	ID      string `xml:"id"`
-	Title   string `xml:"title"`
-	Content struct {
-		Type string `xml:"type,attr"`
-		Body string `xml:",chardata"`
-	} `xml:"content"`
-	Links []struct {
-		Href string `xml:"href,attr"`
-		Rel  string `xml:"rel,attr"`
-	} `xml:"link"`
-}
-
 func (g githubReleaseOrCommit) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
 	repoURL := fmt.Sprintf("https://github.com/%s", g.Repo)
 	feedURL := fmt.Sprintf("%s/releases.atom", repoURL)
@@ -64,44 +50,150 @@ func (g githubReleaseOrCommit) Check(ctx context.Context, prevSeen string, opts
 		return fb, nil
 	}
 
-	entry := feed.Entries[0]
-	title := strings.TrimSpace(entry.Title)
-	if title == "" {
-		title = strings.TrimSpace(entry.ID)
+	releases := g.filterEntries(feed.Entries, g.prereleaseFlags(ctx))
+	if len(releases) == 0 {
+		return Result{}, fmt.Errorf("no release in feed matches (%s)", g.Filter.describe())
 	}
+	entry := releases[0].Entry
+	title := entryTitle(entry)
 	if title == "" {
 		return Result{}, fmt.Errorf("atom: missing entry title/id")
 	}
 
-	releaseLink := ""
-	for _, l := range entry.Links {
-		if l.Rel == "" || l.Rel == "alternate" {
-			releaseLink = l.Href
-			break
-		}
-	}
-	if releaseLink == "" && len(entry.Links) > 0 {
-		releaseLink = entry.Links[0].Href
-	}
-
 	links := map[string]string{"repo": repoURL, "feed": feedURL}
-	if releaseLink != "" {
+	if releaseLink := entryLink(entry); releaseLink != "" {
 		links["release"] = releaseLink
 	}
 
 	msg := fmt.Sprintf("latest release %s", title)
 	prev := strings.TrimSpace(prevSeen)
 	highlights := ""
+	var skipped []Release
 	if prev != "" && prev != title {
 		msg = fmt.Sprintf("new release %s", title)
 		if opts.IncludeNotes {
 			highlights = extractHighlightsFromHTML(entry.Content.Body)
 		}
+		skipped = releasesSince(releases, prev, opts)
 	}
-	return Result{
+	res := Result{
 		Current:    title,
 		Message:    msg,
 		Links:      links,
 		Highlights: highlights,
-	}, nil
+		Skipped:    skipped,
+	}
+	for _, r := range releases {
+		res.Versions = append(res.Versions, r.Version)
+	}
+	if g.Filter.TagPattern != nil {
+		res.Version = releases[0].Version
+	}
+	return res, nil
+}
+
+type feedRelease struct {
+	Entry   atomEntry
+	Version string
+}
+
+// filterEntries returns the feed entries whose tag passes the filter (newest
+// first), with the version extracted from each tag. flags holds the API
+// prerelease flag per tag when known; other tags are guessed from the name.
+func (g githubReleaseOrCommit) filterEntries(entries []atomEntry, flags map[string]bool) []feedRelease {
+	var out []feedRelease
+	for _, e := range entries {
+		tag := entryTag(e)
+		prerelease, known := flags[tag]
+		if !known {
+			prerelease = g.Filter.guessPrerelease(tag)
+		}
+		if v, ok := g.Filter.pass(tag, prerelease); ok {
+			out = append(out, feedRelease{Entry: e, Version: v})
+		}
+	}
+	return out
+}
+
+// releasesSince walks releases (newest first) back to prevSeen and returns
+// every release newer than it. It returns nil when prevSeen is not in the
+// feed, since then the real number of releases is unknown.
+func releasesSince(releases []feedRelease, prevSeen string, opts Options) []Release {
+	var out []Release
+	for _, r := range releases {
+		if entryTitle(r.Entry) == prevSeen {
+			return out
+		}
+		rel := Release{
+			Tag:  entryTag(r.Entry),
+			Date: strings.TrimSpace(r.Entry.Updated),
+			Link: entryLink(r.Entry),
+		}
+		if opts.IncludeNotes {
+			rel.Highlights = extractHighlightsFromHTML(r.Entry.Content.Body)
+		}
+		out = append(out, rel)
+	}
+	return nil
+}
+
+type githubReleaseResp struct {
+	TagName    string `json:"tag_name"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// prereleaseFlags asks the releases API which tags are marked prerelease.
+// Only used with a token (the anonymous API rate limit is tiny); failures fall
+// back to guessing from tag names.
+func (g githubReleaseOrCommit) prereleaseFlags(ctx context.Context) map[string]bool {
+	if strings.TrimSpace(g.Token) == "" || g.Filter.IncludePrereleases {
+		return nil
+	}
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/releases?per_page=100", g.Repo)
+	body, err := g.HTTP.Get(ctx, apiURL, map[string]string{
+		"User-Agent":    g.UserAgent,
+		"Accept":        "application/vnd.github+json",
+		"Authorization": "Bearer " + g.Token,
+	})
+	if err != nil {
+		return nil
+	}
+	var releases []githubReleaseResp
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil
+	}
+	flags := make(map[string]bool, len(releases))
+	for _, r := range releases {
+		flags[r.TagName] = r.Prerelease
+	}
+	return flags
+}
+
+func entryTitle(e atomEntry) string {
+	if t := strings.TrimSpace(e.Title); t != "" {
+		return t
+	}
+	return strings.TrimSpace(e.ID)
+}
+
+func entryLink(e atomEntry) string {
+	for _, l := range e.Links {
+		if l.Rel == "" || l.Rel == "alternate" {
+			return l.Href
+		}
+	}
+	if len(e.Links) > 0 {
+		return e.Links[0].Href
+	}
+	return ""
+}
+
+// entryTag returns the release tag, which GitHub puts at the end of the entry id
+// (tag:github.com,2008:Repository/123/v1.2.3). Falls back to the title.
+func entryTag(e atomEntry) string {
+	id := strings.TrimSpace(e.ID)
+	if i := strings.LastIndex(id, "/"); i >= 0 && i < len(id)-1 {
+		return id[i+1:]
+	}
+	return strings.TrimSpace(e.Title)
 }
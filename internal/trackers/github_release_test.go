@@ -2,10 +2,12 @@ package trackers
 
 import (
 	"context"
+	"regexp"
 	"testing"
 
 	"github.com/peeomid/update-tracker/internal/execx"
 	"github.com/peeomid/update-tracker/internal/httpx"
+	"github.com/peeomid/update-tracker/internal/version"
 )
 
 type fakeFetcher struct {
@@ -68,3 +70,145 @@ func TestGitHubAtomParsing(t *testing.T) {
 }
 
 var _ httpx.Fetcher = fakeFetcher{}
+
+func TestGitHubReleaseConstraintPicksNewestInLine(t *testing.T) {
+	atom := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry><id>tag:github.com,2008:Repository/1/v3.0.0</id><title>v3.0.0</title></entry>
+  <entry><id>tag:github.com,2008:Repository/1/v2.4.1</id><title>Release 2.4.1</title></entry>
+  <entry><id>tag:github.com,2008:Repository/1/v2.4.0</id><title>v2.4.0</title></entry>
+</feed>`
+
+	c, err := version.ParseConstraint("^2.0")
+	if err != nil {
+		t.Fatalf("constraint: %v", err)
+	}
+	tr := githubReleaseOrCommit{
+		HTTP:   fakeFetcher{Body: []byte(atom)},
+		Repo:   "a/b",
+		Filter: releaseFilter{Constraint: c},
+	}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "Release 2.4.1" {
+		t.Fatalf("current=%q", res.Current)
+	}
+}
+
+func TestNpmConstraintUsesVersionList(t *testing.T) {
+	c, err := version.ParseConstraint("~1.4")
+	if err != nil {
+		t.Fatalf("constraint: %v", err)
+	}
+	tr := npmPackage{
+		Exec:    fakeRunner{Out: `{"version":"2.0.0","versions":["1.3.0","1.4.0","1.4.7","1.5.0-beta.1","1.5.0","2.0.0"]}`},
+		Package: "x",
+		Filter:  releaseFilter{Constraint: c},
+	}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "1.4.7" {
+		t.Fatalf("current=%q", res.Current)
+	}
+}
+
+func TestGitHubReleaseSkipsPrereleases(t *testing.T) {
+	atom := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry><id>tag:github.com,2008:Repository/1/v2.0.0-rc.1</id><title>v2.0.0-rc.1</title></entry>
+  <entry><id>tag:github.com,2008:Repository/1/v1.9.1</id><title>v1.9.1</title></entry>
+  <entry><id>tag:github.com,2008:Repository/1/v1.9.0</id><title>v1.9.0</title></entry>
+</feed>`
+	api := `[{"tag_name":"v2.0.0-rc.1","prerelease":true},{"tag_name":"v1.9.1","prerelease":true},{"tag_name":"v1.9.0","prerelease":false}]`
+	f := mapFetcher{ByURL: map[string][]byte{
+		"https://github.com/a/b/releases.atom":                   []byte(atom),
+		"https://api.github.com/repos/a/b/releases?per_page=100": []byte(api),
+	}}
+
+	cases := []struct {
+		token   string
+		include bool
+		want    string
+	}{
+		{"", false, "v1.9.1"},
+		{"t", false, "v1.9.0"},
+		{"", true, "v2.0.0-rc.1"},
+	}
+	for _, tc := range cases {
+		tr := githubReleaseOrCommit{
+			HTTP:   f,
+			Token:  tc.token,
+			Repo:   "a/b",
+			Filter: releaseFilter{IncludePrereleases: tc.include},
+		}
+		res, err := tr.Check(context.Background(), "", Options{})
+		if err != nil {
+			t.Fatalf("check: %v", err)
+		}
+		if res.Current != tc.want {
+			t.Fatalf("token=%q include=%t: current=%q want %q", tc.token, tc.include, res.Current, tc.want)
+		}
+	}
+}
+
+func TestGitHubReleaseTagPattern(t *testing.T) {
+	atom := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry><id>tag:github.com,2008:Repository/1/desktop-v3.0.0</id><title>desktop-v3.0.0</title></entry>
+  <entry><id>tag:github.com,2008:Repository/1/sdk-v0.9.1</id><title>sdk-v0.9.1</title></entry>
+  <entry><id>tag:github.com,2008:Repository/1/cli-v1.3.0-canary</id><title>cli-v1.3.0-canary</title></entry>
+  <entry><id>tag:github.com,2008:Repository/1/cli-v1.2.0</id><title>CLI 1.2.0</title></entry>
+</feed>`
+
+	tr := githubReleaseOrCommit{
+		HTTP: fakeFetcher{Body: []byte(atom)},
+		Repo: "a/b",
+		Filter: releaseFilter{
+			TagPattern: regexp.MustCompile(`^cli-v(?P<version>.+)$`),
+			TagExclude: regexp.MustCompile(`canary`),
+		},
+	}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "CLI 1.2.0" || res.Version != "1.2.0" {
+		t.Fatalf("current=%q version=%q", res.Current, res.Version)
+	}
+}
+
+func TestGitHubReleaseSkippedSincePrev(t *testing.T) {
+	atom := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry><id>tag:github.com,2008:Repository/1/v1.6.0</id><title>v1.6.0</title><updated>2026-02-03T00:00:00Z</updated>
+    <content type="html"><![CDATA[<ul><li>C</li></ul>]]></content></entry>
+  <entry><id>tag:github.com,2008:Repository/1/v1.5.1</id><title>v1.5.1</title></entry>
+  <entry><id>tag:github.com,2008:Repository/1/v1.5.0</id><title>v1.5.0</title>
+    <content type="html"><![CDATA[<ul><li>A</li></ul>]]></content></entry>
+  <entry><id>tag:github.com,2008:Repository/1/v1.4.0</id><title>v1.4.0</title></entry>
+</feed>`
+
+	tr := githubReleaseOrCommit{HTTP: fakeFetcher{Body: []byte(atom)}, Repo: "a/b"}
+	res, err := tr.Check(context.Background(), "v1.4.0", Options{IncludeNotes: true})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if len(res.Skipped) != 3 {
+		t.Fatalf("skipped=%d", len(res.Skipped))
+	}
+	if res.Skipped[0].Tag != "v1.6.0" || res.Skipped[0].Date == "" || res.Skipped[2].Highlights != "- A" {
+		t.Fatalf("skipped=%+v", res.Skipped)
+	}
+
+	res, err = tr.Check(context.Background(), "v0.9.0", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Skipped != nil {
+		t.Fatalf("expected no skipped releases when prev is not in the feed")
+	}
+}
@@ -0,0 +1,193 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+// gitlabAPI is shared by the gitlab trackers: base URL (gitlab.com or a
+// self-hosted instance), project path and optional token.
+type gitlabAPI struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	BaseURL   string
+	Token     string
+	Project   string
+}
+
+func (g gitlabAPI) webURL() string {
+	return fmt.Sprintf("%s/%s", g.base(), g.Project)
+}
+
+func (g gitlabAPI) base() string {
+	base := strings.TrimRight(strings.TrimSpace(g.BaseURL), "/")
+	if base == "" {
+		base = "https://gitlab.com"
+	}
+	return base
+}
+
+func (g gitlabAPI) get(ctx context.Context, path string, out any) error {
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s%s", g.base(), url.PathEscape(g.Project), path)
+	headers := map[string]string{
+		"User-Agent": g.UserAgent,
+		"Accept":     "application/json",
+	}
+	if strings.TrimSpace(g.Token) != "" {
+		headers["PRIVATE-TOKEN"] = g.Token
+	}
+	body, err := g.HTTP.Get(ctx, apiURL, headers)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+type gitlabReleaseResp struct {
+	TagName         string `json:"tag_name"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	ReleasedAt      string `json:"released_at"`
+	UpcomingRelease bool   `json:"upcoming_release"`
+	Links           struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+}
+
+type gitlabRelease struct {
+	API      gitlabAPI
+	Filter   releaseFilter
+	Fallback gitlabCommit
+}
+
+func (g gitlabRelease) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	var all []gitlabReleaseResp
+	if err := g.API.get(ctx, "/releases?per_page=100", &all); err != nil {
+		return Result{}, fmt.Errorf("fetch releases: %w", err)
+	}
+	repoURL := g.API.webURL()
+
+	// GitLab has no prerelease flag; upcoming releases (released_at in the
+	// future) are skipped and the rest is guessed from the tag.
+	var releases []forgeRelease
+	for _, r := range all {
+		releases = append(releases, forgeRelease{
+			Tag:         r.TagName,
+			Notes:       r.Description,
+			Date:        r.ReleasedAt,
+			Link:        forgeLink(r.Links.Self, repoURL, "/-/releases/"+url.PathEscape(r.TagName)),
+			Prerelease:  g.Filter.guessPrerelease(r.TagName),
+			Unpublished: r.UpcomingRelease,
+		})
+	}
+	return forgeReleaseCheck(ctx, releases, g.Filter, g.Fallback, repoURL, prevSeen, opts)
+}
+
+type gitlabCommit struct {
+	API    gitlabAPI
+	Branch string
+}
+
+func (g gitlabCommit) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	var br forgeBranchResp
+	if err := g.API.get(ctx, "/repository/branches/"+url.PathEscape(g.Branch), &br); err != nil {
+		return Result{}, fmt.Errorf("fetch branch %s: %w", g.Branch, err)
+	}
+	return forgeCommitResult(br, g.Branch, g.API.webURL(), "/-/compare", prevSeen)
+}
+
+type gitlabMR struct {
+	API gitlabAPI
+	MR  int
+}
+
+type gitlabMRResp struct {
+	IID          int    `json:"iid"`
+	State        string `json:"state"` // opened|closed|merged|locked
+	Draft        bool   `json:"draft"`
+	WebURL       string `json:"web_url"`
+	SHA          string `json:"sha"`
+	HeadPipeline *struct {
+		Status string `json:"status"`
+	} `json:"head_pipeline"`
+}
+
+type gitlabPipelineResp struct {
+	Status string `json:"status"`
+}
+
+func (g gitlabMR) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	var mr gitlabMRResp
+	if err := g.API.get(ctx, "/merge_requests/"+strconv.Itoa(g.MR), &mr); err != nil {
+		return Result{}, fmt.Errorf("fetch mr: %w", err)
+	}
+	if mr.IID == 0 {
+		mr.IID = g.MR
+	}
+
+	state := strings.ToLower(strings.TrimSpace(mr.State))
+	if state == "" {
+		state = "unknown"
+	}
+	pipeline := g.pipelineSummary(ctx, mr)
+	currentSeen := fmt.Sprintf("%s|draft=%t|checks=%s", state, mr.Draft, pipeline)
+
+	repoURL := g.API.webURL()
+	mrURL := strings.TrimSpace(mr.WebURL)
+	if mrURL == "" {
+		mrURL = repoURL + "/-/merge_requests/" + strconv.Itoa(mr.IID)
+	}
+
+	msg := fmt.Sprintf("MR !%d %s, pipeline=%s", mr.IID, state, pipeline)
+	if mr.Draft {
+		msg = fmt.Sprintf("MR !%d %s (draft), pipeline=%s", mr.IID, state, pipeline)
+	}
+	return Result{
+		Current: currentSeen,
+		Message: msg,
+		Links: map[string]string{
+			"repo": repoURL,
+			"mr":   mrURL,
+		},
+	}, nil
+}
+
+// pipelineSummary maps the MR's head pipeline to success|failure|pending|none,
+// like githubPR.checksSummary. head_pipeline is only returned to authenticated
+// users, so fall back to the MR pipelines list.
+func (g gitlabMR) pipelineSummary(ctx context.Context, mr gitlabMRResp) string {
+	if mr.HeadPipeline != nil {
+		return summarizePipelineStatus(mr.HeadPipeline.Status)
+	}
+	var pipelines []gitlabPipelineResp
+	if err := g.API.get(ctx, fmt.Sprintf("/merge_requests/%d/pipelines", mr.IID), &pipelines); err != nil {
+		// Don't fail the whole tracker because the pipelines endpoint failed.
+		return "unknown"
+	}
+	if len(pipelines) == 0 {
+		return "none"
+	}
+	return summarizePipelineStatus(pipelines[0].Status)
+}
+
+func summarizePipelineStatus(status string) string {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "success", "skipped", "manual":
+		return "success"
+	case "failed", "canceled":
+		return "failure"
+	case "":
+		return "none"
+	default:
+		// created|waiting_for_resource|preparing|pending|running|scheduled
+		return "pending"
+	}
+}
@@ -0,0 +1,57 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGitLabReleaseSkipsUpcomingAndPrereleases(t *testing.T) {
+	releases := `[
+  {"tag_name": "v17.0.0", "released_at": "2099-01-01T00:00:00Z", "upcoming_release": true},
+  {"tag_name": "v16.2.0-rc1", "released_at": "2026-03-01T00:00:00Z"},
+  {"tag_name": "v16.1.0", "released_at": "2026-02-01T00:00:00Z", "description": "## Highlights\n- Faster **runners**\n- [Docs](https://example.com) refresh",
+   "_links": {"self": "https://gitlab.example.com/grp/sub/proj/-/releases/v16.1.0"}},
+  {"tag_name": "v16.0.1", "released_at": "2026-01-15T00:00:00Z"},
+  {"tag_name": "v16.0.0", "released_at": "2026-01-01T00:00:00Z"}
+]`
+	f := mapFetcher{ByURL: map[string][]byte{
+		"https://gitlab.example.com/api/v4/projects/grp%2Fsub%2Fproj/releases?per_page=100": []byte(releases),
+	}}
+	tr := gitlabRelease{API: gitlabAPI{HTTP: f, BaseURL: "https://gitlab.example.com/", Project: "grp/sub/proj"}}
+
+	res, err := tr.Check(context.Background(), "v16.0.0", Options{IncludeNotes: true})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "v16.1.0" || res.Message != "new release v16.1.0" {
+		t.Fatalf("current=%q message=%q", res.Current, res.Message)
+	}
+	if res.Highlights != "- Faster runners\n- Docs refresh" {
+		t.Fatalf("highlights=%q", res.Highlights)
+	}
+	if len(res.Skipped) != 2 || res.Skipped[1].Tag != "v16.0.1" {
+		t.Fatalf("skipped=%+v", res.Skipped)
+	}
+	if res.Links["release"] != "https://gitlab.example.com/grp/sub/proj/-/releases/v16.1.0" {
+		t.Fatalf("links=%v", res.Links)
+	}
+}
+
+func TestGitLabMRPipelineFallback(t *testing.T) {
+	f := mapFetcher{ByURL: map[string][]byte{
+		"https://gitlab.com/api/v4/projects/a%2Fb/merge_requests/7":           []byte(`{"iid": 7, "state": "opened", "draft": true, "web_url": "https://gitlab.com/a/b/-/merge_requests/7"}`),
+		"https://gitlab.com/api/v4/projects/a%2Fb/merge_requests/7/pipelines": []byte(`[{"status": "running"}, {"status": "failed"}]`),
+	}}
+	tr := gitlabMR{API: gitlabAPI{HTTP: f, Project: "a/b"}, MR: 7}
+
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "opened|draft=true|checks=pending" {
+		t.Fatalf("current=%q", res.Current)
+	}
+	if res.Message != "MR !7 opened (draft), pipeline=pending" {
+		t.Fatalf("message=%q", res.Message)
+	}
+}
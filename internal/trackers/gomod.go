@@ -0,0 +1,93 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+type goModule struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	Proxy     string
+	Module    string
+	Filter    releaseFilter
+}
+
+type goProxyInfo struct {
+	Version string `json:"Version"`
+	Time    string `json:"Time"`
+}
+
+func (g goModule) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	base := strings.TrimRight(g.Proxy, "/")
+	if base == "" {
+		base = "https://proxy.golang.org"
+	}
+	modURL := base + "/" + escapeModulePath(g.Module)
+	headers := map[string]string{"User-Agent": g.UserAgent}
+
+	body, err := g.HTTP.Get(ctx, modURL+"/@v/list", headers)
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch module versions: %w", err)
+	}
+	var published []string
+	for _, line := range strings.Split(string(body), "\n") {
+		v := strings.TrimSpace(line)
+		if v != "" && g.Filter.allows(v) {
+			published = append(published, v)
+		}
+	}
+
+	version, ok := g.Filter.newest(published)
+	if !ok {
+		if len(strings.TrimSpace(string(body))) > 0 {
+			return Result{}, fmt.Errorf("gomod: no version matches (%s)", g.Filter.describe())
+		}
+		// No tagged versions: @latest resolves to a pseudo-version of the default branch.
+		body, err := g.HTTP.Get(ctx, modURL+"/@latest", headers)
+		if err != nil {
+			return Result{}, fmt.Errorf("fetch module @latest: %w", err)
+		}
+		var info goProxyInfo
+		if err := json.Unmarshal(body, &info); err != nil {
+			return Result{}, fmt.Errorf("parse module @latest: %w", err)
+		}
+		version = strings.TrimSpace(info.Version)
+		if version == "" {
+			return Result{}, fmt.Errorf("gomod: @latest has no version")
+		}
+	}
+
+	msg := fmt.Sprintf("latest %s", version)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != version {
+		msg = fmt.Sprintf("new version %s", version)
+	}
+	return Result{
+		Current:  version,
+		Message:  msg,
+		Links:    map[string]string{"pkg": "https://pkg.go.dev/" + g.Module},
+		Versions: published,
+	}, nil
+}
+
+// escapeModulePath applies the module proxy case encoding: each upper-case
+// letter becomes "!" followed by its lower-case form.
+func escapeModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGoModuleVersionList(t *testing.T) {
+	tr := goModule{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://proxy.golang.org/github.com/!burnt!sushi/toml/@v/list": []byte("v1.3.2\nv1.4.0\nv1.4.1-rc.1\nv1.3.0\n"),
+		}},
+		Module: "github.com/BurntSushi/toml",
+	}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "v1.4.0" {
+		t.Fatalf("current=%q", res.Current)
+	}
+	if res.Links["pkg"] != "https://pkg.go.dev/github.com/BurntSushi/toml" {
+		t.Fatalf("links=%v", res.Links)
+	}
+}
+
+func TestGoModulePseudoVersionFallback(t *testing.T) {
+	tr := goModule{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://example.com/proxy/example.com/tool/@v/list": []byte(""),
+			"https://example.com/proxy/example.com/tool/@latest": []byte(`{"Version":"v0.0.0-20260101000000-abcdefabcdef","Time":"2026-01-01T00:00:00Z"}`),
+		}},
+		Proxy:  "https://example.com/proxy/",
+		Module: "example.com/tool",
+	}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "v0.0.0-20260101000000-abcdefabcdef" {
+		t.Fatalf("current=%q", res.Current)
+	}
+}
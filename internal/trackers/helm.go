@@ -0,0 +1,92 @@
+package trackers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+// helmChart follows the newest version of a chart in a classic (index.yaml)
+// chart repository.
+type helmChart struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	URL       string
+	Chart     string
+	Filter    releaseFilter
+}
+
+type helmIndex struct {
+	Entries map[string][]helmChartVersion `yaml:"entries"`
+}
+
+type helmChartVersion struct {
+	Version    string `yaml:"version"`
+	AppVersion string `yaml:"appVersion"`
+	Home       string `yaml:"home"`
+	Deprecated bool   `yaml:"deprecated"`
+}
+
+func (h helmChart) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	indexURL := strings.TrimRight(h.URL, "/") + "/index.yaml"
+	body, err := h.HTTP.Get(ctx, indexURL, map[string]string{
+		"User-Agent": h.UserAgent,
+		"Accept":     "application/yaml, text/yaml;q=0.9, */*;q=0.8",
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch helm index: %w", err)
+	}
+
+	var index helmIndex
+	if err := yaml.Unmarshal(body, &index); err != nil {
+		return Result{}, fmt.Errorf("parse helm index: %w", err)
+	}
+	entries, ok := index.Entries[h.Chart]
+	if !ok {
+		return Result{}, fmt.Errorf("chart %s not found in %s", h.Chart, indexURL)
+	}
+
+	var published []string
+	byVersion := map[string]helmChartVersion{}
+	for _, e := range entries {
+		v := strings.TrimSpace(e.Version)
+		if v == "" || !h.Filter.allows(v) {
+			continue
+		}
+		published = append(published, v)
+		byVersion[v] = e
+	}
+	latest, ok := h.Filter.newest(published)
+	if !ok {
+		return Result{}, fmt.Errorf("helm: no chart version matches (%s)", h.Filter.describe())
+	}
+	entry := byVersion[latest]
+
+	msg := fmt.Sprintf("latest %s", latest)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != latest {
+		msg = fmt.Sprintf("new version %s", latest)
+	}
+	if app := strings.TrimSpace(entry.AppVersion); app != "" {
+		msg += fmt.Sprintf(" (app %s)", app)
+	}
+	if entry.Deprecated {
+		msg += ", chart deprecated"
+	}
+
+	links := map[string]string{"repo": strings.TrimRight(h.URL, "/")}
+	if home := strings.TrimSpace(entry.Home); home != "" {
+		links["home"] = home
+	}
+	return Result{
+		Current:  latest,
+		Message:  msg,
+		Links:    links,
+		Versions: published,
+	}, nil
+}
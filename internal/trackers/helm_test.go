@@ -0,0 +1,49 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHelmChartNewest(t *testing.T) {
+	index := `apiVersion: v1
+entries:
+  ingress-nginx:
+  - version: 4.11.0-beta.1
+    appVersion: 1.11.0-beta.1
+  - version: 4.10.1
+    appVersion: 1.10.1
+    home: https://github.com/kubernetes/ingress-nginx
+  - version: 4.9.1
+    appVersion: 1.9.6
+  - version: 4.10.0
+    appVersion: 1.10.0
+  other:
+  - version: 9.0.0
+`
+	tr := helmChart{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://kubernetes.github.io/ingress-nginx/index.yaml": []byte(index),
+		}},
+		URL:   "https://kubernetes.github.io/ingress-nginx/",
+		Chart: "ingress-nginx",
+	}
+	res, err := tr.Check(context.Background(), "4.10.0", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "4.10.1" || res.Message != "new version 4.10.1 (app 1.10.1)" {
+		t.Fatalf("current=%q message=%q", res.Current, res.Message)
+	}
+	if res.Links["home"] != "https://github.com/kubernetes/ingress-nginx" || res.Links["repo"] != "https://kubernetes.github.io/ingress-nginx" {
+		t.Fatalf("links=%v", res.Links)
+	}
+	if len(res.Versions) != 3 {
+		t.Fatalf("versions=%v", res.Versions)
+	}
+
+	tr.Chart = "missing"
+	if _, err := tr.Check(context.Background(), "", Options{}); err == nil {
+		t.Fatalf("expected error for missing chart")
+	}
+}
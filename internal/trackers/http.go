@@ -0,0 +1,120 @@
+package trackers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+// httpJSON reads a version from any JSON endpoint.
+type httpJSON struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	URL       string
+	// Headers values may reference set env vars ($TOKEN, ${TOKEN}).
+	Headers  map[string]string
+	JSONPath string
+	LinkPath string
+	Filter   releaseFilter
+}
+
+func (h httpJSON) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	headers := map[string]string{
+		"User-Agent": h.UserAgent,
+		"Accept":     "application/json",
+	}
+	for k, v := range h.Headers {
+		headers[k] = expandSetEnv(v)
+	}
+	body, err := h.HTTP.Get(ctx, h.URL, headers)
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch %s: %w", h.URL, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return Result{}, fmt.Errorf("parse json: %w", err)
+	}
+
+	val, err := lookupJSONPath(doc, h.JSONPath)
+	if err != nil {
+		return Result{}, err
+	}
+	var current string
+	var versions []string
+	if list, ok := val.([]any); ok {
+		// A list of versions: pick the newest one that passes the filter.
+		for _, item := range list {
+			if s, ok := jsonScalar(item); ok && s != "" && h.Filter.allows(s) {
+				versions = append(versions, s)
+			}
+		}
+		newest, ok := h.Filter.newest(versions)
+		if !ok {
+			return Result{}, fmt.Errorf("jsonPath %s: no version matches (%s)", h.JSONPath, h.Filter.describe())
+		}
+		current = newest
+	} else {
+		s, ok := jsonScalar(val)
+		if !ok {
+			return Result{}, fmt.Errorf("jsonPath %s: got %s, want a string or number", h.JSONPath, jsonKind(val))
+		}
+		// A single value has no older fallback, so a filtered-out one is an error.
+		if s != "" && !h.Filter.allows(s) {
+			return Result{}, fmt.Errorf("jsonPath %s: %s does not match (%s)", h.JSONPath, s, h.Filter.describe())
+		}
+		current = s
+	}
+	if current == "" {
+		return Result{}, fmt.Errorf("jsonPath %s: empty value", h.JSONPath)
+	}
+
+	links := map[string]string{}
+	if strings.TrimSpace(h.LinkPath) != "" {
+		// The link is a nice-to-have; a missing one doesn't fail the check.
+		if lv, err := lookupJSONPath(doc, h.LinkPath); err == nil {
+			if s, ok := jsonScalar(lv); ok && s != "" {
+				links["release"] = s
+			}
+		}
+	}
+
+	msg := fmt.Sprintf("latest %s", current)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != current {
+		msg = fmt.Sprintf("new version %s", current)
+	}
+	return Result{
+		Current:  current,
+		Message:  msg,
+		Links:    links,
+		Versions: versions,
+	}, nil
+}
+
+var envRefRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// expandSetEnv replaces $VAR and ${VAR} with the value of VAR when it is set.
+// Anything else, including a literal "$" or an unset name, is kept as is.
+func expandSetEnv(s string) string {
+	return envRefRe.ReplaceAllStringFunc(s, func(ref string) string {
+		m := envRefRe.FindStringSubmatch(ref)
+		name := m[1]
+		if name == "" {
+			name = m[2]
+		}
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		return ref
+	})
+}
@@ -0,0 +1,90 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/peeomid/update-tracker/internal/version"
+)
+
+func TestHTTPJSONPath(t *testing.T) {
+	body := `{"current_version": "1.9.3", "current_download_url": "https://releases.example.com/app/1.9.3/",
+  "builds": [{"version": "1.9.3", "meta": {"os.arch": "linux_amd64"}}], "build": 4711}`
+	f := mapFetcher{ByURL: map[string][]byte{"https://checkpoint.example.com/v1/check/app": []byte(body)}}
+
+	cases := []struct {
+		path, want string
+	}{
+		{"$.current_version", "1.9.3"},
+		{"current_version", "1.9.3"},
+		{"$.builds[0].version", "1.9.3"},
+		{"builds.-1.meta['os.arch']", "linux_amd64"},
+		{"$.build", "4711"},
+	}
+	for _, tc := range cases {
+		tr := httpJSON{HTTP: f, URL: "https://checkpoint.example.com/v1/check/app", JSONPath: tc.path, LinkPath: "$.current_download_url"}
+		res, err := tr.Check(context.Background(), "", Options{})
+		if err != nil {
+			t.Fatalf("%s: %v", tc.path, err)
+		}
+		if res.Current != tc.want {
+			t.Fatalf("%s: current=%q want %q", tc.path, res.Current, tc.want)
+		}
+		if res.Links["release"] != "https://releases.example.com/app/1.9.3/" {
+			t.Fatalf("links=%v", res.Links)
+		}
+	}
+
+	tr := httpJSON{HTTP: f, URL: "https://checkpoint.example.com/v1/check/app", JSONPath: "$.builds[0]"}
+	if _, err := tr.Check(context.Background(), "", Options{}); err == nil {
+		t.Fatalf("expected error for object value")
+	}
+}
+
+func TestHTTPJSONVersionList(t *testing.T) {
+	f := mapFetcher{ByURL: map[string][]byte{"https://api.example.com/versions": []byte(`{"versions": ["1.2.0", "1.10.0", "2.0.0-beta.1", "1.9.0"]}`)}}
+	tr := httpJSON{HTTP: f, URL: "https://api.example.com/versions", JSONPath: "versions"}
+	res, err := tr.Check(context.Background(), "1.9.0", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "1.10.0" || res.Message != "new version 1.10.0" || len(res.Versions) != 3 {
+		t.Fatalf("res=%+v", res)
+	}
+}
+
+func TestExpandSetEnv(t *testing.T) {
+	t.Setenv("UPD_TEST_TOKEN", "s3cr3t")
+	cases := map[string]string{
+		"Bearer $UPD_TEST_TOKEN":       "Bearer s3cr3t",
+		"Bearer ${UPD_TEST_TOKEN}":     "Bearer s3cr3t",
+		"Bearer $UPD_TEST_UNSET":       "Bearer $UPD_TEST_UNSET",
+		"pa$$word $5 ${not valid} $":   "pa$$word $5 ${not valid} $",
+		"${UPD_TEST_TOKEN}x$UPD_TEST_": "s3cr3tx$UPD_TEST_",
+	}
+	for in, want := range cases {
+		if got := expandSetEnv(in); got != want {
+			t.Fatalf("%q: got %q want %q", in, got, want)
+		}
+	}
+}
+
+func TestHTTPJSONScalarFilter(t *testing.T) {
+	f := mapFetcher{ByURL: map[string][]byte{"https://api.example.com/latest": []byte(`{"version": "3.0.0", "beta": "3.1.0-beta.2"}`)}}
+	c, err := version.ParseConstraint("^2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := httpJSON{HTTP: f, URL: "https://api.example.com/latest", JSONPath: "version", Filter: releaseFilter{Constraint: c}}
+	if _, err := tr.Check(context.Background(), "", Options{}); err == nil {
+		t.Fatalf("expected an error for a version outside the constraint")
+	}
+	tr = httpJSON{HTTP: f, URL: "https://api.example.com/latest", JSONPath: "beta"}
+	if _, err := tr.Check(context.Background(), "", Options{}); err == nil {
+		t.Fatalf("expected an error for an excluded prerelease")
+	}
+	tr.Filter.IncludePrereleases = true
+	if res, err := tr.Check(context.Background(), "", Options{}); err != nil || res.Current != "3.1.0-beta.2" {
+		t.Fatalf("current=%q err=%v", res.Current, err)
+	}
+}
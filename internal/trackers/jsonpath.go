@@ -0,0 +1,103 @@
+package trackers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lookupJSONPath evaluates a small JSONPath subset against a decoded JSON
+// document: an optional leading "$", dotted keys, [n] indexes (negative counts
+// from the end) and ['key'] / ["key"] for keys with dots. Plain dotted paths
+// (data.0.version) work too.
+func lookupJSONPath(doc any, path string) (any, error) {
+	segs, err := splitJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	cur := doc
+	for _, seg := range segs {
+		switch v := cur.(type) {
+		case map[string]any:
+			next, ok := v[seg]
+			if !ok {
+				return nil, fmt.Errorf("jsonPath %s: key %q not found", path, seg)
+			}
+			cur = next
+		case []any:
+			i, err := strconv.Atoi(seg)
+			if err != nil {
+				return nil, fmt.Errorf("jsonPath %s: %q is not an array index", path, seg)
+			}
+			if i < 0 {
+				i += len(v)
+			}
+			if i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("jsonPath %s: index %s out of range (len %d)", path, seg, len(v))
+			}
+			cur = v[i]
+		default:
+			return nil, fmt.Errorf("jsonPath %s: cannot descend into %s at %q", path, jsonKind(cur), seg)
+		}
+	}
+	return cur, nil
+}
+
+func splitJSONPath(path string) ([]string, error) {
+	p := strings.TrimSpace(path)
+	p = strings.TrimPrefix(p, "$")
+	var segs []string
+	for p != "" {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+		case '[':
+			end := strings.IndexByte(p, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("jsonPath %s: unclosed [", path)
+			}
+			seg := strings.TrimSpace(p[1:end])
+			if len(seg) >= 2 && (seg[0] == '\'' || seg[0] == '"') && seg[len(seg)-1] == seg[0] {
+				seg = seg[1 : len(seg)-1]
+			}
+			segs = append(segs, seg)
+			p = p[end+1:]
+		default:
+			end := strings.IndexAny(p, ".[")
+			if end < 0 {
+				end = len(p)
+			}
+			segs = append(segs, p[:end])
+			p = p[end:]
+		}
+	}
+	return segs, nil
+}
+
+// jsonScalar formats a string, number or bool JSON value.
+func jsonScalar(v any) (string, bool) {
+	switch x := v.(type) {
+	case string:
+		return strings.TrimSpace(x), true
+	case json.Number:
+		return x.String(), true
+	case bool:
+		return strconv.FormatBool(x), true
+	default:
+		return "", false
+	}
+}
+
+func jsonKind(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case nil:
+		return "null"
+	default:
+		return "a scalar"
+	}
+}
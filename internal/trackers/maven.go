@@ -0,0 +1,132 @@
+package trackers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+	"github.com/peeomid/update-tracker/internal/version"
+)
+
+// mavenArtifact follows a groupId:artifactId in a Maven repository (Central,
+// Nexus, Artifactory) through its maven-metadata.xml.
+type mavenArtifact struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	Registry  string
+	// Coordinates is groupId:artifactId.
+	Coordinates string
+	Filter      releaseFilter
+}
+
+const mavenCentral = "https://repo1.maven.org/maven2"
+
+type mavenMetadata struct {
+	Versioning struct {
+		Latest   string   `xml:"latest"`
+		Release  string   `xml:"release"`
+		Versions []string `xml:"versions>version"`
+	} `xml:"versioning"`
+}
+
+// Milestones, release candidates and early-access builds; platform
+// qualifiers like -jre, .Final or .RELEASE are releases.
+var mavenPreRe = regexp.MustCompile(`(?i)[.-](alpha|beta|milestone|rc|cr|m|a|b|ea|preview|dev)[.-]?[0-9]*([.-]|$)`)
+
+func (m mavenArtifact) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	group, artifact, ok := strings.Cut(m.Coordinates, ":")
+	if !ok {
+		return Result{}, fmt.Errorf("maven: package must be groupId:artifactId, got %q", m.Coordinates)
+	}
+	base := strings.TrimRight(m.Registry, "/")
+	if base == "" {
+		base = mavenCentral
+	}
+	dir := fmt.Sprintf("%s/%s/%s", base, strings.ReplaceAll(group, ".", "/"), artifact)
+	body, err := m.HTTP.Get(ctx, dir+"/maven-metadata.xml", map[string]string{
+		"User-Agent": m.UserAgent,
+		"Accept":     "application/xml, text/xml;q=0.9, */*;q=0.8",
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch maven metadata: %w", err)
+	}
+
+	var meta mavenMetadata
+	if err := xml.Unmarshal(body, &meta); err != nil {
+		return Result{}, fmt.Errorf("parse maven metadata: %w", err)
+	}
+
+	// <release> is the last deployed release, which may be a backport, so it
+	// only competes with the version list rather than winning outright.
+	candidates := append([]string{meta.Versioning.Release}, meta.Versioning.Versions...)
+	var published []string
+	seen := map[string]bool{}
+	best := ""
+	var bestV version.Version
+	for _, raw := range candidates {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || seen[raw] {
+			continue
+		}
+		seen[raw] = true
+		key, ok := m.pass(raw)
+		if !ok {
+			continue
+		}
+		published = append(published, raw)
+		v, ok := version.Extract(key)
+		if !ok {
+			continue
+		}
+		if best == "" || version.Compare(v, bestV) > 0 {
+			best, bestV = raw, v
+		}
+	}
+	if best == "" {
+		return Result{}, fmt.Errorf("maven: no release matches (%s)", m.Filter.describe())
+	}
+
+	links := map[string]string{"repo": dir + "/"}
+	if base == mavenCentral {
+		links["maven"] = fmt.Sprintf("https://central.sonatype.com/artifact/%s/%s/%s", group, artifact, best)
+	}
+	msg := fmt.Sprintf("latest %s", best)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != best {
+		msg = fmt.Sprintf("new version %s", best)
+	}
+	return Result{
+		Current:  best,
+		Message:  msg,
+		Links:    links,
+		Versions: published,
+	}, nil
+}
+
+// pass applies the filter to a Maven version. SNAPSHOTs never pass. Release
+// qualifiers are dropped (33.0.0-jre is checked as 33.0.0) so constraints
+// don't treat them as prereleases; the returned key is what gets compared.
+func (m mavenArtifact) pass(raw string) (string, bool) {
+	if strings.HasSuffix(strings.ToUpper(raw), "-SNAPSHOT") {
+		return "", false
+	}
+	key := raw
+	pre := mavenPreRe.MatchString(raw)
+	if !pre {
+		if v, ok := version.Extract(raw); ok {
+			parts := make([]string, len(v.Release))
+			for i, n := range v.Release {
+				parts[i] = fmt.Sprint(n)
+			}
+			key = strings.Join(parts, ".")
+		}
+	}
+	if _, ok := m.Filter.pass(key, pre); !ok {
+		return "", false
+	}
+	return key, true
+}
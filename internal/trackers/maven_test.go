@@ -0,0 +1,79 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/peeomid/update-tracker/internal/version"
+)
+
+func TestMavenSkipsSnapshotsAndMilestones(t *testing.T) {
+	meta := `<?xml version="1.0" encoding="UTF-8"?>
+<metadata>
+  <groupId>com.google.guava</groupId>
+  <artifactId>guava</artifactId>
+  <versioning>
+    <latest>34.0.0-SNAPSHOT</latest>
+    <release>32.1.3-android</release>
+    <versions>
+      <version>32.1.3-jre</version>
+      <version>32.1.3-android</version>
+      <version>33.0.0-rc1</version>
+      <version>33.0.0-jre</version>
+      <version>33.1.0-M1</version>
+      <version>34.0.0-SNAPSHOT</version>
+    </versions>
+  </versioning>
+</metadata>`
+	tr := mavenArtifact{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://repo1.maven.org/maven2/com/google/guava/guava/maven-metadata.xml": []byte(meta),
+		}},
+		Coordinates: "com.google.guava:guava",
+	}
+	res, err := tr.Check(context.Background(), "32.1.3-jre", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "33.0.0-jre" || res.Message != "new version 33.0.0-jre" {
+		t.Fatalf("current=%q message=%q", res.Current, res.Message)
+	}
+	if res.Links["maven"] != "https://central.sonatype.com/artifact/com.google.guava/guava/33.0.0-jre" {
+		t.Fatalf("links=%v", res.Links)
+	}
+	if len(res.Versions) != 3 {
+		t.Fatalf("versions=%v", res.Versions)
+	}
+
+	c, err := version.ParseConstraint("~32.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.Filter = releaseFilter{Constraint: c}
+	if res, err = tr.Check(context.Background(), "", Options{}); err != nil || res.Current != "32.1.3-android" {
+		t.Fatalf("constraint: current=%q err=%v", res.Current, err)
+	}
+
+	tr.Filter = releaseFilter{IncludePrereleases: true}
+	if res, err = tr.Check(context.Background(), "", Options{}); err != nil || res.Current != "33.1.0-M1" {
+		t.Fatalf("prereleases: current=%q err=%v", res.Current, err)
+	}
+}
+
+func TestMavenCustomRegistry(t *testing.T) {
+	meta := `<metadata><versioning><release>2.1.0.RELEASE</release><versions><version>2.0.0.RELEASE</version><version>2.1.0.RELEASE</version></versions></versioning></metadata>`
+	tr := mavenArtifact{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://nexus.example.com/repository/maven-public/org/example/lib/maven-metadata.xml": []byte(meta),
+		}},
+		Registry:    "https://nexus.example.com/repository/maven-public/",
+		Coordinates: "org.example:lib",
+	}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "2.1.0.RELEASE" || res.Links["maven"] != "" {
+		t.Fatalf("current=%q links=%v", res.Current, res.Links)
+	}
+}
@@ -2,6 +2,7 @@ package trackers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -11,15 +12,23 @@ import (
 type npmPackage struct {
 	Exec    execx.Runner
 	Package string
+	Filter  releaseFilter
 }
 
 func (n npmPackage) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
 	_ = opts
-	out, err := n.Exec.Run(ctx, "npm", "view", n.Package, "version")
+	view, err := n.view(ctx)
 	if err != nil {
-		return Result{}, fmt.Errorf("npm view: %w", err)
+		return Result{}, err
+	}
+	version := strings.TrimSpace(view.Version)
+	if !n.Filter.Constraint.IsZero() {
+		v, ok := n.Filter.newest(view.Versions)
+		if !ok {
+			return Result{}, fmt.Errorf("npm view: no version matches (%s)", n.Filter.describe())
+		}
+		version = v
 	}
-	version := strings.TrimSpace(out)
 	if version == "" {
 		return Result{}, fmt.Errorf("npm view: empty version")
 	}
@@ -29,9 +38,62 @@ func (n npmPackage) Check(ctx context.Context, prevSeen string, opts Options) (R
 	if prev != "" && prev != version {
 		msg = fmt.Sprintf("new version %s", version)
 	}
+	if !n.Filter.Constraint.IsZero() {
+		msg += fmt.Sprintf(" (%s)", n.Filter.Constraint)
+	}
+	var published []string
+	for _, v := range view.Versions {
+		if n.Filter.allows(v) {
+			published = append(published, v)
+		}
+	}
 	return Result{
-		Current: version,
-		Message: msg,
-		Links:   links,
+		Current:  version,
+		Message:  msg,
+		Links:    links,
+		Versions: published,
 	}, nil
 }
+
+type npmView struct {
+	Version  string
+	Versions []string
+}
+
+// view fetches the latest version and the published version list in one
+// `npm view --json` call.
+func (n npmPackage) view(ctx context.Context) (npmView, error) {
+	out, err := n.Exec.Run(ctx, "npm", "view", n.Package, "--json")
+	if err != nil {
+		return npmView{}, fmt.Errorf("npm view: %w", err)
+	}
+	var raw struct {
+		Version  string          `json:"version"`
+		Versions json.RawMessage `json:"versions"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &raw); err != nil {
+		return npmView{}, fmt.Errorf("npm view json: %w", err)
+	}
+	view := npmView{Version: raw.Version}
+	if len(raw.Versions) > 0 {
+		if view.Versions, err = parseNpmVersionsJSON(string(raw.Versions)); err != nil {
+			return npmView{}, err
+		}
+	}
+	return view, nil
+}
+
+// parseNpmVersionsJSON accepts the array npm prints, or the bare string it
+// prints when a package has a single version.
+func parseNpmVersionsJSON(out string) ([]string, error) {
+	out = strings.TrimSpace(out)
+	var list []string
+	if err := json.Unmarshal([]byte(out), &list); err == nil {
+		return list, nil
+	}
+	var single string
+	if err := json.Unmarshal([]byte(out), &single); err != nil {
+		return nil, fmt.Errorf("npm view versions json: %w", err)
+	}
+	return []string{single}, nil
+}
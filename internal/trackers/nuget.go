@@ -0,0 +1,104 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+type nugetPackage struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	// Registry is the feed's registration base address (RegistrationsBaseUrl).
+	Registry string
+	Package  string
+	Filter   releaseFilter
+}
+
+// The gz-semver2 hive also lists SemVer 2.0.0 versions (1.0.0-beta.1+meta).
+const nugetRegistrationURL = "https://api.nuget.org/v3/registration5-gz-semver2"
+
+// nugetPage is a registration page. Large packages leave Items out of the
+// index and only link the page by ID.
+type nugetPage struct {
+	ID    string `json:"@id"`
+	Items []struct {
+		CatalogEntry struct {
+			Version string `json:"version"`
+			Listed  *bool  `json:"listed"`
+		} `json:"catalogEntry"`
+	} `json:"items"`
+}
+
+func (n nugetPackage) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	base := strings.TrimRight(n.Registry, "/")
+	if base == "" {
+		base = nugetRegistrationURL
+	}
+	// Registration ids are lowercase.
+	apiURL := fmt.Sprintf("%s/%s/index.json", base, url.PathEscape(strings.ToLower(n.Package)))
+	var index struct {
+		Items []nugetPage `json:"items"`
+	}
+	if err := n.getJSON(ctx, apiURL, &index); err != nil {
+		return Result{}, err
+	}
+
+	// Unlisted versions are hidden from search and restore; skip them.
+	var published []string
+	for _, page := range index.Items {
+		if len(page.Items) == 0 && page.ID != "" {
+			if err := n.getJSON(ctx, page.ID, &page); err != nil {
+				return Result{}, err
+			}
+		}
+		for _, item := range page.Items {
+			e := item.CatalogEntry
+			if e.Listed != nil && !*e.Listed {
+				continue
+			}
+			if n.Filter.allows(e.Version) {
+				published = append(published, e.Version)
+			}
+		}
+	}
+	version, ok := n.Filter.newest(published)
+	if !ok {
+		return Result{}, fmt.Errorf("nuget: no listed version matches (%s)", n.Filter.describe())
+	}
+
+	link := apiURL
+	if strings.TrimRight(n.Registry, "/") == "" {
+		link = fmt.Sprintf("https://www.nuget.org/packages/%s/%s", n.Package, version)
+	}
+	msg := fmt.Sprintf("latest %s", version)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != version {
+		msg = fmt.Sprintf("new version %s", version)
+	}
+	return Result{
+		Current:  version,
+		Message:  msg,
+		Links:    map[string]string{"nuget": link},
+		Versions: published,
+	}, nil
+}
+
+func (n nugetPackage) getJSON(ctx context.Context, u string, v any) error {
+	body, err := n.HTTP.Get(ctx, u, map[string]string{
+		"User-Agent": n.UserAgent,
+		"Accept":     "application/json",
+	})
+	if err != nil {
+		return fmt.Errorf("fetch nuget: %w", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("parse nuget json: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package trackers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+func TestNuGetCustomRegistry(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/registration/newtonsoft.json/index.json":
+			// The second page is only linked, as for packages with many versions.
+			_, _ = w.Write([]byte(`{"items": [
+  {"@id": "` + srv.URL + `/v3/registration/newtonsoft.json/page/12.0.3/12.0.3.json", "items": [
+    {"catalogEntry": {"version": "12.0.3", "listed": true}}
+  ]},
+  {"@id": "` + srv.URL + `/v3/registration/newtonsoft.json/page/13.0.1/13.0.5.json"}
+]}`))
+		case "/v3/registration/newtonsoft.json/page/13.0.1/13.0.5.json":
+			_, _ = w.Write([]byte(`{"items": [
+  {"catalogEntry": {"version": "13.0.1"}},
+  {"catalogEntry": {"version": "13.0.3", "listed": true}},
+  {"catalogEntry": {"version": "13.0.4-beta1", "listed": true}},
+  {"catalogEntry": {"version": "13.0.5", "listed": false}}
+]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	tr := nugetPackage{
+		HTTP:     httpx.NewClient(5 * time.Second),
+		Registry: srv.URL + "/v3/registration/",
+		Package:  "Newtonsoft.Json",
+	}
+	res, err := tr.Check(context.Background(), "13.0.1", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	// 13.0.5 is newer but unlisted.
+	if res.Current != "13.0.3" || res.Message != "new version 13.0.3" {
+		t.Fatalf("current=%q message=%q", res.Current, res.Message)
+	}
+	if res.Links["nuget"] != srv.URL+"/v3/registration/newtonsoft.json/index.json" {
+		t.Fatalf("links=%v", res.Links)
+	}
+	if len(res.Versions) != 3 {
+		t.Fatalf("versions=%v", res.Versions)
+	}
+}
@@ -0,0 +1,252 @@
+package trackers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+type ociImage struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	// Registry is the registry base URL; empty means derive it from Image.
+	Registry string
+	Image    string
+	// Tag is a moving tag (latest, 16) whose digest is tracked; optional.
+	Tag    string
+	Filter releaseFilter
+}
+
+type ociTagsResp struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// Manifest lists/indexes first, so the digest matches what `docker pull` records
+// in RepoDigests for multi-arch images.
+var ociManifestAccept = strings.Join([]string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+}, ", ")
+
+func (o ociImage) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	base, name := parseImageRef(o.Image)
+	if strings.TrimSpace(o.Registry) != "" {
+		base = strings.TrimRight(o.Registry, "/")
+	}
+	c := ociClient{HTTP: o.HTTP, UserAgent: o.UserAgent}
+
+	tags, err := c.tags(ctx, fmt.Sprintf("%s/v2/%s/tags/list", base, name))
+	if err != nil {
+		return Result{}, err
+	}
+	var published []string
+	for _, t := range tags {
+		if o.Filter.allows(t) {
+			published = append(published, t)
+		}
+	}
+	newestTag, hasNewest := o.Filter.newest(published)
+
+	links := map[string]string{}
+	if link := imageWebURL(base, name); link != "" {
+		links["image"] = link
+	}
+	prev := strings.TrimSpace(prevSeen)
+
+	if strings.TrimSpace(o.Tag) == "" {
+		if !hasNewest {
+			return Result{}, fmt.Errorf("oci: no tag matches (%s)", o.Filter.describe())
+		}
+		msg := fmt.Sprintf("newest tag %s", newestTag)
+		if prev != "" && prev != newestTag {
+			msg = fmt.Sprintf("new tag %s", newestTag)
+		}
+		res := Result{Current: newestTag, Message: msg, Links: links, Versions: published}
+		if v, ok := o.Filter.matchTag(newestTag); ok && o.Filter.TagPattern != nil {
+			res.Version = v
+		}
+		return res, nil
+	}
+
+	manifest, _, err := c.get(ctx, fmt.Sprintf("%s/v2/%s/manifests/%s", base, name, url.PathEscape(o.Tag)), ociManifestAccept)
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch manifest %s: %w", o.Tag, err)
+	}
+	sum := sha256.Sum256(manifest)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	ref := fmt.Sprintf("%s:%s", o.Image, o.Tag)
+	msg := fmt.Sprintf("%s digest %s", ref, ShortDigest(digest))
+	if prev != "" && prev != digest {
+		msg = fmt.Sprintf("%s has a new digest %s", ref, ShortDigest(digest))
+	}
+	if hasNewest {
+		msg += fmt.Sprintf(" (newest tag %s)", newestTag)
+	}
+	return Result{Current: digest, Message: msg, Links: links}, nil
+}
+
+// ociClient does registry GETs with the anonymous bearer token flow: on a 401
+// it follows the WWW-Authenticate challenge to the token realm and retries.
+type ociClient struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	token     string
+}
+
+var authParamRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// ociMaxTagPages bounds tags/list pagination for registries with huge tag lists.
+const ociMaxTagPages = 50
+
+// tags fetches tags/list, following Link rel="next" pages (GHCR, Harbor).
+func (c *ociClient) tags(ctx context.Context, u string) ([]string, error) {
+	var all []string
+	for page := 0; u != "" && page < ociMaxTagPages; page++ {
+		body, header, err := c.get(ctx, u, "application/json")
+		if err != nil {
+			return nil, fmt.Errorf("fetch tags: %w", err)
+		}
+		var resp ociTagsResp
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("parse tags json: %w", err)
+		}
+		all = append(all, resp.Tags...)
+		u = nextPageURL(u, header.Get("Link"))
+	}
+	return all, nil
+}
+
+var linkNextRe = regexp.MustCompile(`<([^>]+)>\s*;[^,]*rel="?next"?`)
+
+// nextPageURL resolves the rel="next" target of a Link header against the
+// current URL, or returns "" on the last page.
+func nextPageURL(current string, link string) string {
+	m := linkNextRe.FindStringSubmatch(link)
+	if m == nil {
+		return ""
+	}
+	base, err := url.Parse(current)
+	if err != nil {
+		return ""
+	}
+	next, err := base.Parse(m[1])
+	if err != nil {
+		return ""
+	}
+	return next.String()
+}
+
+func (c *ociClient) get(ctx context.Context, u string, accept string) ([]byte, http.Header, error) {
+	headers := map[string]string{"User-Agent": c.UserAgent, "Accept": accept}
+	if c.token != "" {
+		headers["Authorization"] = "Bearer " + c.token
+	}
+	body, header, err := c.fetch(ctx, u, headers)
+	var se *httpx.StatusError
+	if err == nil || c.token != "" || !errors.As(err, &se) || se.Code != http.StatusUnauthorized {
+		return body, header, err
+	}
+
+	challenge := se.Header.Get("WWW-Authenticate")
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return nil, nil, err
+	}
+	params := map[string]string{}
+	for _, m := range authParamRe.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(m[1])] = m[2]
+	}
+	if params["realm"] == "" {
+		return nil, nil, fmt.Errorf("%w: auth challenge without realm", err)
+	}
+	q := url.Values{}
+	for _, k := range []string{"service", "scope"} {
+		if params[k] != "" {
+			q.Set(k, params[k])
+		}
+	}
+	tokenURL := params["realm"]
+	if len(q) > 0 {
+		tokenURL += "?" + q.Encode()
+	}
+	tb, terr := c.HTTP.Get(ctx, tokenURL, map[string]string{"User-Agent": c.UserAgent})
+	if terr != nil {
+		return nil, nil, fmt.Errorf("fetch registry token: %w", terr)
+	}
+	var tok struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(tb, &tok); err != nil {
+		return nil, nil, fmt.Errorf("parse registry token: %w", err)
+	}
+	c.token = tok.Token
+	if c.token == "" {
+		c.token = tok.AccessToken
+	}
+	if c.token == "" {
+		return nil, nil, fmt.Errorf("registry token: empty")
+	}
+	return c.get(ctx, u, accept)
+}
+
+// fetch uses GetWithHeader when the fetcher supports it; otherwise there are
+// no headers and pagination stops after the first page.
+func (c *ociClient) fetch(ctx context.Context, u string, headers map[string]string) ([]byte, http.Header, error) {
+	if hf, ok := c.HTTP.(httpx.HeaderFetcher); ok {
+		return hf.GetWithHeader(ctx, u, headers)
+	}
+	body, err := c.HTTP.Get(ctx, u, headers)
+	return body, http.Header{}, err
+}
+
+// parseImageRef splits an image reference into a registry base URL and a
+// repository name, following docker's rules: no registry host means Docker
+// Hub, and single-name Hub images live under library/.
+func parseImageRef(image string) (string, string) {
+	image = strings.TrimSpace(image)
+	if i := strings.IndexByte(image, '@'); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	host := "docker.io"
+	name := image
+	if i := strings.Index(image, "/"); i >= 0 {
+		first := image[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			host, name = first, image[i+1:]
+		}
+	}
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+		if !strings.Contains(name, "/") {
+			name = "library/" + name
+		}
+	}
+	return "https://" + host, name
+}
+
+func imageWebURL(base string, name string) string {
+	if base != "https://registry-1.docker.io" {
+		return ""
+	}
+	if strings.HasPrefix(name, "library/") {
+		return "https://hub.docker.com/_/" + strings.TrimPrefix(name, "library/")
+	}
+	return "https://hub.docker.com/r/" + name
+}
@@ -0,0 +1,94 @@
+package trackers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+// newTestRegistry serves tags and a manifest for library/postgres, requiring
+// a bearer token obtained through the anonymous token flow.
+func newTestRegistry(t *testing.T, manifest string) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("scope") != "repository:library/postgres:pull" {
+			http.Error(w, "bad scope", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"token":"t0k"}`)
+	})
+	authed := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer t0k" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:library/postgres:pull"`, srv.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next(w, r)
+		}
+	}
+	mux.HandleFunc("/v2/library/postgres/tags/list", authed(func(w http.ResponseWriter, r *http.Request) {
+		// Two pages, linked like GHCR/Harbor do.
+		if r.URL.Query().Get("last") == "" {
+			w.Header().Set("Link", `</v2/library/postgres/tags/list?last=16.3&n=3>; rel="next"`)
+			fmt.Fprint(w, `{"name":"library/postgres","tags":["latest","16","16.3"]}`)
+			return
+		}
+		fmt.Fprint(w, `{"name":"library/postgres","tags":["16.4","16.4-alpine","17beta1","15.8"]}`)
+	}))
+	mux.HandleFunc("/v2/library/postgres/manifests/16", authed(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, manifest)
+	}))
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOCIDigestOfMovingTag(t *testing.T) {
+	manifest := `{"schemaVersion":2,"manifests":[]}`
+	srv := newTestRegistry(t, manifest)
+
+	tr := ociImage{
+		HTTP:     httpx.NewClient(5 * time.Second),
+		Registry: srv.URL,
+		Image:    "postgres",
+		Tag:      "16",
+	}
+	res, err := tr.Check(context.Background(), "sha256:old", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	sum := sha256.Sum256([]byte(manifest))
+	if want := "sha256:" + hex.EncodeToString(sum[:]); res.Current != want {
+		t.Fatalf("current=%q want %q", res.Current, want)
+	}
+	if res.Message != "postgres:16 has a new digest "+ShortDigest(res.Current)+" (newest tag 16.4)" {
+		t.Fatalf("message=%q", res.Message)
+	}
+}
+
+func TestParseImageRef(t *testing.T) {
+	cases := []struct {
+		image, base, name string
+	}{
+		{"postgres", "https://registry-1.docker.io", "library/postgres"},
+		{"grafana/grafana:11.0.0", "https://registry-1.docker.io", "grafana/grafana"},
+		{"ghcr.io/org/app", "https://ghcr.io", "org/app"},
+		{"localhost:5000/app:dev", "https://localhost:5000", "app"},
+	}
+	for _, tc := range cases {
+		base, name := parseImageRef(tc.image)
+		if base != tc.base || name != tc.name {
+			t.Fatalf("%s: got %s %s", tc.image, base, name)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+type packagistPackage struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	Registry  string
+	// Package is vendor/name.
+	Package string
+	Filter  releaseFilter
+}
+
+type packagistResp struct {
+	Packages map[string][]struct {
+		Version string `json:"version"`
+	} `json:"packages"`
+}
+
+func (p packagistPackage) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	base := strings.TrimRight(p.Registry, "/")
+	if base == "" {
+		base = "https://repo.packagist.org"
+	}
+	// The Composer v2 metadata only lists tagged releases (dev branches live in
+	// the ~dev.json file).
+	apiURL := fmt.Sprintf("%s/p2/%s.json", base, strings.ToLower(p.Package))
+	body, err := p.HTTP.Get(ctx, apiURL, map[string]string{
+		"User-Agent": p.UserAgent,
+		"Accept":     "application/json",
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch packagist: %w", err)
+	}
+
+	var resp packagistResp
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return Result{}, fmt.Errorf("parse packagist json: %w", err)
+	}
+	entries, ok := resp.Packages[strings.ToLower(p.Package)]
+	if !ok {
+		return Result{}, fmt.Errorf("packagist: package %s not found", p.Package)
+	}
+
+	var published []string
+	for _, e := range entries {
+		v := strings.TrimSpace(e.Version)
+		if v != "" && !strings.HasPrefix(v, "dev-") && p.Filter.allows(v) {
+			published = append(published, v)
+		}
+	}
+	version, ok := p.Filter.newest(published)
+	if !ok {
+		return Result{}, fmt.Errorf("packagist: no version matches (%s)", p.Filter.describe())
+	}
+
+	link := fmt.Sprintf("https://packagist.org/packages/%s", p.Package)
+	if base != "https://repo.packagist.org" {
+		link = apiURL
+	}
+	msg := fmt.Sprintf("latest %s", version)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != version {
+		msg = fmt.Sprintf("new version %s", version)
+	}
+	return Result{
+		Current:  version,
+		Message:  msg,
+		Links:    map[string]string{"packagist": link},
+		Versions: published,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPackagistNewestStable(t *testing.T) {
+	body := `{"packages": {"laravel/framework": [
+  {"version": "v11.0.0-RC1"},
+  {"version": "v10.48.4"},
+  {"version": "v10.48.3"},
+  {"version": "dev-master"}
+]}}`
+	tr := packagistPackage{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://repo.packagist.org/p2/laravel/framework.json": []byte(body),
+		}},
+		Package: "laravel/framework",
+	}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "v10.48.4" {
+		t.Fatalf("current=%q", res.Current)
+	}
+	if res.Links["packagist"] != "https://packagist.org/packages/laravel/framework" {
+		t.Fatalf("links=%v", res.Links)
+	}
+
+	tr.Package = "laravel/missing"
+	if _, err := tr.Check(context.Background(), "", Options{}); err == nil {
+		t.Fatalf("expected error for missing package")
+	}
+}
@@ -0,0 +1,260 @@
+package trackers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+// pageScraper reads a version (or just "something changed") from an HTML page.
+// Selector narrows the page to one element's text, Pattern extracts from
+// that (or from the raw page), and Hash tracks a digest of the result instead.
+type pageScraper struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	URL       string
+	Selector  string
+	Pattern   *regexp.Regexp
+	Hash      bool
+	Filter    releaseFilter
+}
+
+// Longer values are almost certainly not a version; ask for pattern or hash.
+const maxPageValue = 200
+
+func (p pageScraper) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	body, err := p.HTTP.Get(ctx, p.URL, map[string]string{
+		"User-Agent": p.UserAgent,
+		"Accept":     "text/html,application/xhtml+xml;q=0.9,*/*;q=0.8",
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch page: %w", err)
+	}
+
+	region := string(body)
+	if strings.TrimSpace(p.Selector) != "" {
+		text, ok, err := selectText(region, p.Selector)
+		if err != nil {
+			return Result{}, err
+		}
+		if !ok {
+			return Result{}, fmt.Errorf("selector %s matched nothing", p.Selector)
+		}
+		region = text
+	}
+
+	var versions []string
+	if p.Pattern != nil {
+		matches := p.Pattern.FindAllStringSubmatch(region, -1)
+		if len(matches) == 0 {
+			return Result{}, fmt.Errorf("pattern %s matched nothing", p.Pattern)
+		}
+		for _, m := range matches {
+			versions = append(versions, submatchValue(p.Pattern, m))
+		}
+		// Download pages often list several versions; take the newest when
+		// the matches are versions, else the first match.
+		region = versions[0]
+		if newest, ok := p.Filter.newest(versions); ok {
+			region = newest
+		}
+	}
+	value := strings.Join(strings.Fields(region), " ")
+
+	links := map[string]string{"page": p.URL}
+	prev := strings.TrimSpace(prevSeen)
+	if p.Hash {
+		sum := sha256.Sum256([]byte(value))
+		digest := "sha256:" + shortSHA(hex.EncodeToString(sum[:]))
+		msg := fmt.Sprintf("content %s", digest)
+		if prev != "" && prev != digest {
+			msg = "page changed"
+		}
+		return Result{Current: digest, Message: msg, Links: links}, nil
+	}
+
+	if value == "" {
+		return Result{}, fmt.Errorf("page: extracted value is empty")
+	}
+	if len(value) > maxPageValue {
+		return Result{}, fmt.Errorf("page: extracted value is %d chars; narrow it with pattern or set hash: true", len(value))
+	}
+	msg := fmt.Sprintf("latest %s", value)
+	if prev != "" && prev != value {
+		msg = fmt.Sprintf("new version %s", value)
+	}
+	var published []string
+	seen := map[string]bool{}
+	for _, v := range versions {
+		if !seen[v] && p.Filter.allows(v) {
+			published = append(published, v)
+		}
+		seen[v] = true
+	}
+	return Result{Current: value, Message: msg, Links: links, Versions: published}, nil
+}
+
+// submatchValue returns the "version" group, else the first group, else the
+// whole match.
+func submatchValue(re *regexp.Regexp, m []string) string {
+	if i := re.SubexpIndex("version"); i > 0 && m[i] != "" {
+		return m[i]
+	}
+	if len(m) > 1 && m[1] != "" {
+		return m[1]
+	}
+	return m[0]
+}
+
+// selector is a single compound CSS selector: tag, #id, .class and
+// [attr] / [attr=value] parts, e.g. span.version or meta[name=version].
+type selector struct {
+	Tag     string
+	ID      string
+	Classes []string
+	Attrs   map[string]*string
+}
+
+var (
+	selectorRe     = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9-]*)?((?:[.#][\w-]+|\[[\w:-]+(?:=(?:"[^"]*"|'[^']*'|[^\]]*))?\])*)$`)
+	selectorPartRe = regexp.MustCompile(`[.#][\w-]+|\[([\w:-]+)(?:=("[^"]*"|'[^']*'|[^\]]*))?\]`)
+	openTagRe      = regexp.MustCompile(`<([a-zA-Z][a-zA-Z0-9-]*)(\s[^>]*)?>`)
+	attrRe         = regexp.MustCompile(`([\w:-]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+)))?`)
+	anyTagRe       = regexp.MustCompile(`(?is)<script.*?</script>|<style.*?</style>|<[^>]+>`)
+)
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+func parseSelector(s string) (selector, error) {
+	s = strings.TrimSpace(s)
+	m := selectorRe.FindStringSubmatch(s)
+	if s == "" || m == nil {
+		return selector{}, fmt.Errorf("unsupported selector %q (use tag, #id, .class, [attr=value])", s)
+	}
+	sel := selector{Tag: strings.ToLower(m[1]), Attrs: map[string]*string{}}
+	for _, part := range selectorPartRe.FindAllStringSubmatch(m[2], -1) {
+		switch part[0][0] {
+		case '#':
+			sel.ID = part[0][1:]
+		case '.':
+			sel.Classes = append(sel.Classes, part[0][1:])
+		default:
+			name := strings.ToLower(part[1])
+			if strings.Contains(part[0], "=") {
+				v := strings.Trim(part[2], `"'`)
+				sel.Attrs[name] = &v
+			} else {
+				sel.Attrs[name] = nil
+			}
+		}
+	}
+	return sel, nil
+}
+
+func (sel selector) matches(tag string, attrs map[string]string) bool {
+	if sel.Tag != "" && sel.Tag != tag {
+		return false
+	}
+	if sel.ID != "" && attrs["id"] != sel.ID {
+		return false
+	}
+	classes := strings.Fields(attrs["class"])
+	for _, c := range sel.Classes {
+		found := false
+		for _, have := range classes {
+			if have == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for name, want := range sel.Attrs {
+		have, ok := attrs[name]
+		if !ok || (want != nil && have != *want) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectText returns the text of the first element matching sel. Void
+// elements (meta, input) have no text, so their content/value attribute is
+// used instead.
+func selectText(page string, sel string) (string, bool, error) {
+	s, err := parseSelector(sel)
+	if err != nil {
+		return "", false, err
+	}
+	for _, loc := range openTagRe.FindAllStringSubmatchIndex(page, -1) {
+		tag := strings.ToLower(page[loc[2]:loc[3]])
+		attrs := map[string]string{}
+		if loc[4] >= 0 {
+			for _, a := range attrRe.FindAllStringSubmatch(page[loc[4]:loc[5]], -1) {
+				attrs[strings.ToLower(a[1])] = html.UnescapeString(a[2] + a[3] + a[4])
+			}
+		}
+		if !s.matches(tag, attrs) {
+			continue
+		}
+		if voidElements[tag] || strings.HasSuffix(page[loc[0]:loc[1]], "/>") {
+			if v, ok := attrs["content"]; ok {
+				return strings.TrimSpace(v), true, nil
+			}
+			return strings.TrimSpace(attrs["value"]), true, nil
+		}
+		inner := page[loc[1]:]
+		if end := closingTag(inner, tag); end >= 0 {
+			inner = inner[:end]
+		}
+		return htmlText(inner), true, nil
+	}
+	return "", false, nil
+}
+
+// closingTag finds the offset of the </tag> that closes an element whose
+// content starts at s, skipping nested elements with the same name.
+func closingTag(s string, tag string) int {
+	lower := strings.ToLower(s)
+	depth := 0
+	for i := 0; i < len(lower); {
+		j := strings.IndexByte(lower[i:], '<')
+		if j < 0 {
+			return -1
+		}
+		i += j
+		rest := lower[i+1:]
+		switch {
+		case strings.HasPrefix(rest, "/"+tag) && tagNameEnds(rest[1+len(tag):]):
+			if depth == 0 {
+				return i
+			}
+			depth--
+		case strings.HasPrefix(rest, tag) && tagNameEnds(rest[len(tag):]):
+			depth++
+		}
+		i++
+	}
+	return -1
+}
+
+func tagNameEnds(s string) bool {
+	return s == "" || strings.ContainsRune(" \t\r\n>/", rune(s[0]))
+}
+
+func htmlText(s string) string {
+	s = anyTagRe.ReplaceAllString(s, " ")
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
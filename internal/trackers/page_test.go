@@ -0,0 +1,82 @@
+package trackers
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const testPage = `<!doctype html>
+<html><head>
+  <meta name="product-version" content="2026.2.3">
+  <script>var v = "9.9.9";</script>
+</head><body>
+  <div class="release card">
+    <h2>Latest release</h2>
+    <div class="release-version"><span>Version</span> <b>2026.2.3</b></div>
+    <div class="notes">Build 262.1234 &amp; fixes</div>
+  </div>
+  <ul id="downloads">
+    <li><a href="/dl/tool-2026.1.5.tar.gz">tool-2026.1.5.tar.gz</a></li>
+    <li><a href="/dl/tool-2026.2.3.tar.gz">tool-2026.2.3.tar.gz</a></li>
+    <li><a href="/dl/tool-2026.3.0-eap.tar.gz">tool-2026.3.0-eap.tar.gz</a></li>
+  </ul>
+</body></html>`
+
+func TestSelectText(t *testing.T) {
+	cases := []struct {
+		sel, want string
+	}{
+		{"div.release-version", "Version 2026.2.3"},
+		{"meta[name=product-version]", "2026.2.3"},
+		{".release .notes", ""},
+		{"div.notes", "Build 262.1234 & fixes"},
+		{"#downloads", "tool-2026.1.5.tar.gz tool-2026.2.3.tar.gz tool-2026.3.0-eap.tar.gz"},
+		{"div.release", "Latest release Version 2026.2.3 Build 262.1234 & fixes"},
+	}
+	for _, tc := range cases {
+		got, ok, err := selectText(testPage, tc.sel)
+		if tc.want == "" {
+			if err == nil {
+				t.Fatalf("%s: expected unsupported selector error", tc.sel)
+			}
+			continue
+		}
+		if err != nil || !ok || got != tc.want {
+			t.Fatalf("%s: got %q %t %v", tc.sel, got, ok, err)
+		}
+	}
+}
+
+func TestPageScraper(t *testing.T) {
+	f := fakeFetcher{Body: []byte(testPage)}
+	tr := pageScraper{
+		HTTP:    f,
+		URL:     "https://vendor.example.com/download",
+		Pattern: regexp.MustCompile(`tool-(?P<version>[\w.-]+?)\.tar\.gz`),
+	}
+	res, err := tr.Check(context.Background(), "2026.1.5", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	// The eap build is a prerelease and skipped.
+	if res.Current != "2026.2.3" || res.Message != "new version 2026.2.3" || len(res.Versions) != 2 {
+		t.Fatalf("res=%+v", res)
+	}
+
+	tr = pageScraper{HTTP: f, URL: "https://vendor.example.com/download", Selector: "div.notes", Hash: true}
+	res, err = tr.Check(context.Background(), "sha256:000000000000", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if !strings.HasPrefix(res.Current, "sha256:") || len(res.Current) != len("sha256:")+12 || res.Message != "page changed" {
+		t.Fatalf("res=%+v", res)
+	}
+
+	long := fakeFetcher{Body: []byte("<p>" + strings.Repeat("lorem ipsum ", 30) + "</p>")}
+	tr = pageScraper{HTTP: long, URL: "https://vendor.example.com/download", Selector: "p"}
+	if _, err := tr.Check(context.Background(), "", Options{}); err == nil || !strings.Contains(err.Error(), "hash") {
+		t.Fatalf("expected long value error, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+type pypiPackage struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	Registry  string
+	Package   string
+	Filter    releaseFilter
+}
+
+type pypiResp struct {
+	Info struct {
+		Name       string `json:"name"`
+		Version    string `json:"version"`
+		PackageURL string `json:"package_url"`
+	} `json:"info"`
+	Releases map[string][]struct {
+		Yanked bool `json:"yanked"`
+	} `json:"releases"`
+}
+
+func (p pypiPackage) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	base := strings.TrimRight(p.Registry, "/")
+	if base == "" {
+		base = "https://pypi.org"
+	}
+	apiURL := fmt.Sprintf("%s/pypi/%s/json", base, url.PathEscape(p.Package))
+	body, err := p.HTTP.Get(ctx, apiURL, map[string]string{
+		"User-Agent": p.UserAgent,
+		"Accept":     "application/json",
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch pypi: %w", err)
+	}
+
+	var resp pypiResp
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return Result{}, fmt.Errorf("parse pypi json: %w", err)
+	}
+
+	// A release is yanked when all of its files are; releases without files
+	// can't be installed either.
+	var published []string
+	for v, files := range resp.Releases {
+		yanked := true
+		for _, f := range files {
+			if !f.Yanked {
+				yanked = false
+				break
+			}
+		}
+		if !yanked && p.Filter.allows(v) {
+			published = append(published, v)
+		}
+	}
+	version, ok := p.Filter.newest(published)
+	if !ok {
+		return Result{}, fmt.Errorf("pypi: no installable release matches (%s)", p.Filter.describe())
+	}
+
+	projectURL := strings.TrimSpace(resp.Info.PackageURL)
+	if projectURL == "" {
+		projectURL = fmt.Sprintf("%s/project/%s/", base, p.Package)
+	}
+
+	msg := fmt.Sprintf("latest %s", version)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != version {
+		msg = fmt.Sprintf("new version %s", version)
+	}
+	return Result{
+		Current:  version,
+		Message:  msg,
+		Links:    map[string]string{"pypi": projectURL},
+		Versions: published,
+	}, nil
+}
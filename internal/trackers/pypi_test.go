@@ -0,0 +1,60 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPyPISkipsYankedAndPrereleases(t *testing.T) {
+	body := `{
+  "info": {"name": "httpie", "version": "3.2.2", "package_url": "https://pypi.org/project/httpie/"},
+  "releases": {
+    "3.2.1": [{"yanked": false}],
+    "3.2.2": [{"yanked": false}, {"yanked": false}],
+    "3.2.3": [{"yanked": true}],
+    "3.3.0": [],
+    "4.0.0b1": [{"yanked": false}]
+  }
+}`
+	tr := pypiPackage{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://pypi.org/pypi/httpie/json": []byte(body),
+		}},
+		Package: "httpie",
+	}
+	res, err := tr.Check(context.Background(), "3.2.1", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "3.2.2" {
+		t.Fatalf("current=%q", res.Current)
+	}
+	if res.Links["pypi"] != "https://pypi.org/project/httpie/" {
+		t.Fatalf("links=%v", res.Links)
+	}
+	if len(res.Versions) != 2 {
+		t.Fatalf("versions=%v", res.Versions)
+	}
+}
+
+func TestPyPIPostRelease(t *testing.T) {
+	body := `{
+  "info": {"name": "tool", "version": "2.0.post1"},
+  "releases": {
+    "1.9": [{"yanked": false}],
+    "2.0": [{"yanked": false}],
+    "2.0.post1": [{"yanked": false}]
+  }
+}`
+	tr := pypiPackage{
+		HTTP:    mapFetcher{ByURL: map[string][]byte{"https://pypi.org/pypi/tool/json": []byte(body)}},
+		Package: "tool",
+	}
+	res, err := tr.Check(context.Background(), "2.0", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "2.0.post1" || len(res.Versions) != 3 {
+		t.Fatalf("current=%q versions=%v", res.Current, res.Versions)
+	}
+}
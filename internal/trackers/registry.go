@@ -3,16 +3,22 @@ package trackers
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
+	"strings"
 
 	"github.com/peeomid/update-tracker/internal/config"
 	"github.com/peeomid/update-tracker/internal/execx"
 	"github.com/peeomid/update-tracker/internal/httpx"
+	"github.com/peeomid/update-tracker/internal/version"
 )
 
 type Registry struct {
 	HTTP      httpx.Fetcher
 	Exec      execx.Runner
 	UserAgent string
+	// GitHubToken is optional; when set, release trackers use the API prerelease flag.
+	GitHubToken string
 }
 
 type Options struct {
@@ -20,10 +26,39 @@ type Options struct {
 }
 
 type Result struct {
-	Current    string
+	// Current is what the state remembers; a change means "new remote version".
+	Current string
+	// Version is the version part of Current when a tracker knows it better
+	// than the runner's generic extraction (e.g. from a tagPattern group).
+	Version    string
 	Message    string
 	Links      map[string]string
 	Highlights string
+	// Skipped lists every release newer than prevSeen (newest first,
+	// including Current) when the tracker can tell.
+	Skipped []Release
+	// Versions are the published versions the tracker saw (any order); used to
+	// count how far a local install is behind.
+	Versions []string
+	// Cycles are the product's release lines with their end-of-life dates,
+	// newest first (eol tracker only).
+	Cycles []Cycle
+}
+
+type Cycle struct {
+	Name   string `json:"cycle"`
+	Latest string `json:"latest,omitempty"`
+	// EOL is the end-of-life date (YYYY-MM-DD); Ended is set instead when
+	// the cycle is EOL without a known date.
+	EOL   string `json:"eol,omitempty"`
+	Ended bool   `json:"ended,omitempty"`
+}
+
+type Release struct {
+	Tag        string `json:"tag"`
+	Date       string `json:"date,omitempty"`
+	Link       string `json:"link,omitempty"`
+	Highlights string `json:"highlights,omitempty"`
 }
 
 type Tracker interface {
@@ -31,6 +66,11 @@ type Tracker interface {
 }
 
 func (r Registry) Build(cfg config.TrackerEntry) (Tracker, error) {
+	filter, err := newReleaseFilter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	switch cfg.Type {
 	case "github":
 		switch cfg.Mode {
@@ -49,7 +89,9 @@ func (r Registry) Build(cfg config.TrackerEntry) (Tracker, error) {
 				HTTP:      r.HTTP,
 				Exec:      r.Exec,
 				UserAgent: r.UserAgent,
+				Token:     r.GitHubToken,
 				Repo:      cfg.Repo,
+				Filter:    filter,
 				Fallback: githubCommit{
 					Exec:   r.Exec,
 					Repo:   cfg.Repo,
@@ -66,17 +108,260 @@ func (r Registry) Build(cfg config.TrackerEntry) (Tracker, error) {
 		default:
 			return nil, fmt.Errorf("tracker %s: github mode must be release|commit|pr", cfg.Name)
 		}
+	case "gitlab":
+		api := gitlabAPI{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			BaseURL:   cfg.BaseURL,
+			Token:     envToken(cfg.TokenEnv, "GITLAB_TOKEN"),
+			Project:   cfg.Repo,
+		}
+		switch cfg.Mode {
+		case "commit":
+			return gitlabCommit{API: api, Branch: cfg.Branch}, nil
+		case "release":
+			branch := cfg.Branch
+			if branch == "" {
+				branch = "main"
+			}
+			return gitlabRelease{
+				API:      api,
+				Filter:   filter,
+				Fallback: gitlabCommit{API: api, Branch: branch},
+			}, nil
+		case "mr":
+			return gitlabMR{API: api, MR: cfg.PR}, nil
+		default:
+			return nil, fmt.Errorf("tracker %s: gitlab mode must be release|commit|mr", cfg.Name)
+		}
+	case "gitea":
+		api := giteaAPI{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			BaseURL:   cfg.BaseURL,
+			Token:     envToken(cfg.TokenEnv, "GITEA_TOKEN"),
+			Repo:      cfg.Repo,
+		}
+		switch cfg.Mode {
+		case "commit":
+			return giteaCommit{API: api, Branch: cfg.Branch}, nil
+		case "release":
+			branch := cfg.Branch
+			if branch == "" {
+				branch = "main"
+			}
+			return giteaRelease{
+				API:      api,
+				Filter:   filter,
+				Fallback: giteaCommit{API: api, Branch: branch},
+			}, nil
+		case "pr":
+			return giteaPR{API: api, PR: cfg.PR}, nil
+		default:
+			return nil, fmt.Errorf("tracker %s: gitea mode must be release|commit|pr", cfg.Name)
+		}
+	case "git":
+		switch cfg.Mode {
+		case "commit":
+			return gitBranch{Exec: r.Exec, URL: cfg.URL, Branch: cfg.Branch}, nil
+		case "tag":
+			return gitTag{Exec: r.Exec, URL: cfg.URL, Filter: filter}, nil
+		default:
+			return nil, fmt.Errorf("tracker %s: git mode must be commit|tag", cfg.Name)
+		}
+	case "http":
+		return httpJSON{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			URL:       cfg.URL,
+			Headers:   cfg.Headers,
+			JSONPath:  cfg.JSONPath,
+			LinkPath:  cfg.LinkPath,
+			Filter:    filter,
+		}, nil
+	case "feed":
+		tr := feedTracker{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			URL:       cfg.URL,
+			Filter:    filter,
+		}
+		if strings.TrimSpace(cfg.Pattern) != "" {
+			if tr.Pattern, err = regexp.Compile(cfg.Pattern); err != nil {
+				return nil, fmt.Errorf("tracker %s: invalid pattern: %w", cfg.Name, err)
+			}
+		}
+		return tr, nil
+	case "page":
+		tr := pageScraper{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			URL:       cfg.URL,
+			Selector:  cfg.Selector,
+			Hash:      cfg.Hash,
+			Filter:    filter,
+		}
+		if strings.TrimSpace(cfg.Pattern) != "" {
+			if tr.Pattern, err = regexp.Compile(cfg.Pattern); err != nil {
+				return nil, fmt.Errorf("tracker %s: invalid pattern: %w", cfg.Name, err)
+			}
+		}
+		if strings.TrimSpace(cfg.Selector) != "" {
+			if _, err := parseSelector(cfg.Selector); err != nil {
+				return nil, fmt.Errorf("tracker %s: %w", cfg.Name, err)
+			}
+		}
+		return tr, nil
+	case "helm":
+		return helmChart{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			URL:       cfg.URL,
+			Chart:     cfg.Chart,
+			Filter:    filter,
+		}, nil
 	case "brew":
 		return brewFormula{
 			Exec:    r.Exec,
 			Formula: cfg.Formula,
+			Cask:    cfg.Cask,
+			Filter:  filter,
 		}, nil
 	case "npm":
 		return npmPackage{
 			Exec:    r.Exec,
-			Package: cfg.NpmPackage,
+			Package: cfg.Package,
+			Filter:  filter,
+		}, nil
+	case "pypi":
+		return pypiPackage{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			Registry:  cfg.Registry,
+			Package:   cfg.Package,
+			Filter:    filter,
+		}, nil
+	case "crates":
+		return cratesPackage{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			Registry:  cfg.Registry,
+			Crate:     cfg.Package,
+			Filter:    filter,
+		}, nil
+	case "rubygems":
+		return rubyGem{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			Registry:  cfg.Registry,
+			Gem:       cfg.Package,
+			Filter:    filter,
+		}, nil
+	case "packagist":
+		return packagistPackage{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			Registry:  cfg.Registry,
+			Package:   cfg.Package,
+			Filter:    filter,
+		}, nil
+	case "nuget":
+		return nugetPackage{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			Registry:  cfg.Registry,
+			Package:   cfg.Package,
+			Filter:    filter,
+		}, nil
+	case "maven":
+		return mavenArtifact{
+			HTTP:        r.HTTP,
+			UserAgent:   r.UserAgent,
+			Registry:    cfg.Registry,
+			Coordinates: strings.TrimSpace(cfg.Package),
+			Filter:      filter,
+		}, nil
+	case "gomod":
+		return goModule{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			Proxy:     cfg.Registry,
+			Module:    cfg.Module,
+			Filter:    filter,
+		}, nil
+	case "terraform":
+		return terraformRegistry{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			Registry:  cfg.Registry,
+			Module:    cfg.Mode == "module",
+			Source:    cfg.Source,
+			Filter:    filter,
+		}, nil
+	case "eol":
+		return eolProduct{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			Registry:  cfg.Registry,
+			Product:   cfg.Product,
+		}, nil
+	case "oci":
+		image, tag := cfg.ImageRef()
+		return ociImage{
+			HTTP:      r.HTTP,
+			UserAgent: r.UserAgent,
+			Registry:  cfg.Registry,
+			Image:     image,
+			Tag:       tag,
+			Filter:    filter,
 		}, nil
 	default:
 		return nil, fmt.Errorf("tracker %s: unknown type: %s", cfg.Name, cfg.Type)
 	}
 }
+
+// newReleaseFilter builds the tag and version filter from cfg.
+func newReleaseFilter(cfg config.TrackerEntry) (releaseFilter, error) {
+	constraint, err := version.ParseConstraint(cfg.Constraint)
+	if err != nil {
+		return releaseFilter{}, fmt.Errorf("tracker %s: %w", cfg.Name, err)
+	}
+	filter := releaseFilter{
+		Constraint:         constraint,
+		IncludePrereleases: cfg.IncludePrereleases,
+	}
+	if strings.TrimSpace(cfg.TagPattern) != "" {
+		if filter.TagPattern, err = regexp.Compile(cfg.TagPattern); err != nil {
+			return releaseFilter{}, fmt.Errorf("tracker %s: invalid tagPattern: %w", cfg.Name, err)
+		}
+	}
+	if strings.TrimSpace(cfg.TagExclude) != "" {
+		if filter.TagExclude, err = regexp.Compile(cfg.TagExclude); err != nil {
+			return releaseFilter{}, fmt.Errorf("tracker %s: invalid tagExclude: %w", cfg.Name, err)
+		}
+	}
+	return filter, nil
+}
+
+// TagVersion extracts the version from tag with cfg's tagPattern, the way the
+// tracker does. Tags the pattern doesn't match are returned unchanged.
+func TagVersion(cfg config.TrackerEntry, tag string) string {
+	filter, err := newReleaseFilter(cfg)
+	if err != nil {
+		return tag
+	}
+	if v, ok := filter.matchTag(tag); ok {
+		return v
+	}
+	return tag
+}
+
+// envToken reads an access token from the env var named by key, or from def
+// when key is empty.
+func envToken(key string, def string) string {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		key = def
+	}
+	return strings.TrimSpace(os.Getenv(key))
+}
@@ -0,0 +1,69 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+type rubyGem struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	Registry  string
+	Gem       string
+	Filter    releaseFilter
+}
+
+type rubyGemVersion struct {
+	Number     string `json:"number"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+func (g rubyGem) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	base := strings.TrimRight(g.Registry, "/")
+	if base == "" {
+		base = "https://rubygems.org"
+	}
+	// Yanked versions are not listed.
+	apiURL := fmt.Sprintf("%s/api/v1/versions/%s.json", base, url.PathEscape(g.Gem))
+	body, err := g.HTTP.Get(ctx, apiURL, map[string]string{
+		"User-Agent": g.UserAgent,
+		"Accept":     "application/json",
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch rubygems: %w", err)
+	}
+
+	var resp []rubyGemVersion
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return Result{}, fmt.Errorf("parse rubygems json: %w", err)
+	}
+
+	var published []string
+	for _, v := range resp {
+		if n, ok := g.Filter.pass(v.Number, v.Prerelease); ok {
+			published = append(published, n)
+		}
+	}
+	version, ok := g.Filter.newest(published)
+	if !ok {
+		return Result{}, fmt.Errorf("rubygems: no version matches (%s)", g.Filter.describe())
+	}
+
+	msg := fmt.Sprintf("latest %s", version)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != version {
+		msg = fmt.Sprintf("new version %s", version)
+	}
+	return Result{
+		Current:  version,
+		Message:  msg,
+		Links:    map[string]string{"rubygems": fmt.Sprintf("%s/gems/%s/versions/%s", base, g.Gem, version)},
+		Versions: published,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRubyGemSkipsPrereleases(t *testing.T) {
+	body := `[
+  {"number": "8.0.0.beta1", "prerelease": true},
+  {"number": "7.2.1", "prerelease": false},
+  {"number": "7.1.4", "prerelease": false}
+]`
+	tr := rubyGem{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://rubygems.org/api/v1/versions/rails.json": []byte(body),
+		}},
+		Gem: "rails",
+	}
+	res, err := tr.Check(context.Background(), "7.1.4", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "7.2.1" || res.Message != "new version 7.2.1" {
+		t.Fatalf("current=%q message=%q", res.Current, res.Message)
+	}
+	if res.Links["rubygems"] != "https://rubygems.org/gems/rails/versions/7.2.1" {
+		t.Fatalf("links=%v", res.Links)
+	}
+	if len(res.Versions) != 2 {
+		t.Fatalf("versions=%v", res.Versions)
+	}
+}
@@ -0,0 +1,148 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/peeomid/update-tracker/internal/httpx"
+)
+
+// terraformRegistry follows a provider or module in a Terraform/OpenTofu
+// registry (registry protocol v1).
+type terraformRegistry struct {
+	HTTP      httpx.Fetcher
+	UserAgent string
+	Registry  string
+	// Module is true for modules (ns/name/provider), false for providers (ns/type).
+	Module bool
+	Source string
+	Filter releaseFilter
+}
+
+const terraformRegistryURL = "https://registry.terraform.io"
+
+type terraformVersionsResp struct {
+	// providers
+	Versions []struct {
+		Version string `json:"version"`
+	} `json:"versions"`
+	// modules
+	Modules []struct {
+		Versions []struct {
+			Version string `json:"version"`
+		} `json:"versions"`
+	} `json:"modules"`
+}
+
+func (t terraformRegistry) Check(ctx context.Context, prevSeen string, opts Options) (Result, error) {
+	_ = opts
+	base, addr := terraformSource(t.Source)
+	kind := "providers"
+	if t.Module {
+		kind = "modules"
+	}
+	var service string
+	if r := strings.TrimRight(t.Registry, "/"); r != "" {
+		base = r
+		service = fmt.Sprintf("%s/v1/%s/", base, kind)
+	} else {
+		var err error
+		if service, err = t.discover(ctx, base, kind+".v1"); err != nil {
+			return Result{}, err
+		}
+	}
+	apiURL := fmt.Sprintf("%s%s/versions", service, addr)
+	body, err := t.HTTP.Get(ctx, apiURL, map[string]string{
+		"User-Agent": t.UserAgent,
+		"Accept":     "application/json",
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch terraform registry: %w", err)
+	}
+
+	var resp terraformVersionsResp
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return Result{}, fmt.Errorf("parse terraform registry json: %w", err)
+	}
+	var all []string
+	for _, v := range resp.Versions {
+		all = append(all, v.Version)
+	}
+	for _, m := range resp.Modules {
+		for _, v := range m.Versions {
+			all = append(all, v.Version)
+		}
+	}
+
+	var published []string
+	for _, v := range all {
+		if v = strings.TrimSpace(v); v != "" && t.Filter.allows(v) {
+			published = append(published, v)
+		}
+	}
+	version, ok := t.Filter.newest(published)
+	if !ok {
+		return Result{}, fmt.Errorf("terraform registry: no version of %s matches (%s)", addr, t.Filter.describe())
+	}
+
+	links := map[string]string{}
+	if base == terraformRegistryURL {
+		links["registry"] = fmt.Sprintf("%s/%s/%s/%s", base, kind, addr, version)
+	}
+	msg := fmt.Sprintf("latest %s", version)
+	prev := strings.TrimSpace(prevSeen)
+	if prev != "" && prev != version {
+		msg = fmt.Sprintf("new version %s", version)
+	}
+	return Result{
+		Current:  version,
+		Message:  msg,
+		Links:    links,
+		Versions: published,
+	}, nil
+}
+
+// discover looks up a registry service (providers.v1, modules.v1) in the
+// host's /.well-known/terraform.json. Terraform Cloud, for one, serves its
+// private registry under /api/registry/v1/. The result ends in "/".
+func (t terraformRegistry) discover(ctx context.Context, base string, service string) (string, error) {
+	body, err := t.HTTP.Get(ctx, base+"/.well-known/terraform.json", map[string]string{
+		"User-Agent": t.UserAgent,
+		"Accept":     "application/json",
+	})
+	if err != nil {
+		return "", fmt.Errorf("terraform service discovery: %w", err)
+	}
+	var services map[string]any
+	if err := json.Unmarshal(body, &services); err != nil {
+		return "", fmt.Errorf("parse terraform service discovery: %w", err)
+	}
+	path, _ := services[service].(string)
+	if strings.TrimSpace(path) == "" {
+		return "", fmt.Errorf("terraform service discovery: %s has no %s service", base, service)
+	}
+	baseURL, err := url.Parse(base + "/")
+	if err != nil {
+		return "", fmt.Errorf("terraform service discovery: %w", err)
+	}
+	u, err := baseURL.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("terraform service discovery: %s: %w", service, err)
+	}
+	return strings.TrimRight(u.String(), "/") + "/", nil
+}
+
+// terraformSource splits a provider or module source address into the
+// registry base URL and the address within it. A leading hostname
+// (registry.opentofu.org/hashicorp/aws) selects that registry; otherwise it
+// is registry.terraform.io.
+func terraformSource(source string) (base string, addr string) {
+	source = strings.Trim(strings.TrimSpace(source), "/")
+	if host, rest, ok := strings.Cut(source, "/"); ok && strings.Contains(host, ".") {
+		return "https://" + host, rest
+	}
+	return terraformRegistryURL, source
+}
@@ -0,0 +1,81 @@
+package trackers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTerraformProvider(t *testing.T) {
+	body := `{"id": "hashicorp/aws", "versions": [
+  {"version": "5.39.1", "protocols": ["5.0"]},
+  {"version": "5.40.0", "protocols": ["5.0"]},
+  {"version": "6.0.0-beta1", "protocols": ["5.0"]}
+]}`
+	tr := terraformRegistry{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://registry.terraform.io/.well-known/terraform.json":          []byte(`{"modules.v1": "/v1/modules/", "providers.v1": "/v1/providers/"}`),
+			"https://registry.terraform.io/v1/providers/hashicorp/aws/versions": []byte(body),
+		}},
+		Source: "hashicorp/aws",
+	}
+	res, err := tr.Check(context.Background(), "5.39.1", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "5.40.0" || res.Message != "new version 5.40.0" {
+		t.Fatalf("current=%q message=%q", res.Current, res.Message)
+	}
+	if res.Links["registry"] != "https://registry.terraform.io/providers/hashicorp/aws/5.40.0" {
+		t.Fatalf("links=%v", res.Links)
+	}
+}
+
+func TestTerraformModuleOpenTofu(t *testing.T) {
+	body := `{"modules": [{"source": "terraform-aws-modules/vpc/aws", "versions": [{"version": "5.4.0"}, {"version": "5.5.2"}, {"version": "5.5.1"}]}]}`
+	tr := terraformRegistry{
+		HTTP: mapFetcher{ByURL: map[string][]byte{
+			"https://registry.opentofu.org/.well-known/terraform.json":                        []byte(`{"modules.v1": "/v1/modules/", "providers.v1": "/v1/providers/"}`),
+			"https://registry.opentofu.org/v1/modules/terraform-aws-modules/vpc/aws/versions": []byte(body),
+		}},
+		Module: true,
+		Source: "registry.opentofu.org/terraform-aws-modules/vpc/aws",
+	}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "5.5.2" || len(res.Links) != 0 {
+		t.Fatalf("current=%q links=%v", res.Current, res.Links)
+	}
+	if len(res.Versions) != 3 {
+		t.Fatalf("versions=%v", res.Versions)
+	}
+}
+
+func TestTerraformServiceDiscovery(t *testing.T) {
+	body := `{"modules": [{"versions": [{"version": "1.2.0"}, {"version": "1.3.0"}]}]}`
+	f := mapFetcher{ByURL: map[string][]byte{
+		"https://app.terraform.io/.well-known/terraform.json":                    []byte(`{"modules.v1": "/api/registry/v1/modules/", "providers.v1": "/api/registry/v1/providers/"}`),
+		"https://app.terraform.io/api/registry/v1/modules/acme/net/aws/versions": []byte(body),
+		"https://tf.internal.example.com/v1/modules/acme/net/aws/versions":       []byte(body),
+	}}
+	tr := terraformRegistry{HTTP: f, Module: true, Source: "app.terraform.io/acme/net/aws"}
+	res, err := tr.Check(context.Background(), "", Options{})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if res.Current != "1.3.0" {
+		t.Fatalf("current=%q", res.Current)
+	}
+
+	// registry skips discovery.
+	tr.Registry = "https://tf.internal.example.com/"
+	if res, err = tr.Check(context.Background(), "", Options{}); err != nil || res.Current != "1.3.0" {
+		t.Fatalf("override: current=%q err=%v", res.Current, err)
+	}
+
+	tr = terraformRegistry{HTTP: f, Source: "registry.example.com/acme/thing"}
+	if _, err := tr.Check(context.Background(), "", Options{}); err == nil {
+		t.Fatalf("expected a discovery error")
+	}
+}
@@ -13,6 +13,14 @@ func shortSHA(s string) string {
 	return s[:12]
 }
 
+// ShortDigest shortens "sha256:<64 hex>" to 12 hex chars; other values are kept.
+func ShortDigest(s string) string {
+	if strings.HasPrefix(s, "sha256:") && len(s) > len("sha256:")+12 {
+		return s[:len("sha256:")+12]
+	}
+	return s
+}
+
 func extractHighlightsFromHTML(raw string) string {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
@@ -57,3 +65,50 @@ func extractHighlightsFromHTML(raw string) string {
 	}
 	return out
 }
+
+// extractHighlightsFromMarkdown is the markdown counterpart of
+// extractHighlightsFromHTML, for APIs that return release notes as markdown.
+func extractHighlightsFromMarkdown(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+
+	candidates := raw
+	if idx := strings.Index(strings.ToLower(raw), "highlights"); idx >= 0 {
+		candidates = raw[idx:]
+	}
+	lines := markdownBullets(candidates)
+	if len(lines) == 0 && candidates != raw {
+		lines = markdownBullets(raw)
+	}
+
+	out := strings.Join(lines, "\n")
+	if len(out) > 500 {
+		out = out[:500] + "..."
+	}
+	return out
+}
+
+var mdLinkRe = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+
+func markdownBullets(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "- ") && !strings.HasPrefix(line, "* ") {
+			continue
+		}
+		txt := mdLinkRe.ReplaceAllString(line[2:], "$1")
+		txt = strings.NewReplacer("**", "", "`", "").Replace(txt)
+		txt = strings.Join(strings.Fields(txt), " ")
+		if txt == "" {
+			continue
+		}
+		lines = append(lines, "- "+txt)
+		if len(lines) >= 6 {
+			break
+		}
+	}
+	return lines
+}
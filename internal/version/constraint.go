@@ -0,0 +1,197 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Constraint is a version range such as "^2.0", "~1.4", "<3", ">=1.2 <2",
+// "1.4.x" or "^1 || ^2". The zero value matches every version.
+type Constraint struct {
+	raw  string
+	sets [][]comparator
+}
+
+type comparator struct {
+	op string // = > >= < <=
+	v  Version
+}
+
+var (
+	extractRe = regexp.MustCompile(`[0-9]+(\.[0-9]+)+([-+][0-9A-Za-z.+-]+)?`)
+	opRe      = regexp.MustCompile(`^(\^|~|>=|<=|>|<|=)?\s*(.+)$`)
+)
+
+// Extract parses s as a version, or else the first version-looking
+// substring of s ("Release 1.2.3", "cli-v1.2.0").
+func Extract(s string) (Version, bool) {
+	if v, ok := Parse(s); ok {
+		return v, true
+	}
+	if m := extractRe.FindString(s); m != "" {
+		return Parse(m)
+	}
+	return Version{}, false
+}
+
+// ParseConstraint parses a constraint. Terms separated by spaces or commas
+// must all match; "||" separates alternatives.
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{raw: strings.TrimSpace(s)}
+	if c.raw == "" {
+		return c, nil
+	}
+	for _, alt := range strings.Split(c.raw, "||") {
+		// Allow "> = 1.2" style spacing by gluing operators to their version.
+		fields := strings.FieldsFunc(alt, func(r rune) bool { return r == ',' || r == ' ' })
+		var terms []string
+		for i := 0; i < len(fields); i++ {
+			f := fields[i]
+			if strings.Trim(f, "^~<>=") == "" && i+1 < len(fields) {
+				f += fields[i+1]
+				i++
+			}
+			terms = append(terms, f)
+		}
+		if len(terms) == 0 {
+			return Constraint{}, fmt.Errorf("invalid constraint %q: empty alternative", c.raw)
+		}
+		var set []comparator
+		for _, term := range terms {
+			cmps, err := parseTerm(term)
+			if err != nil {
+				return Constraint{}, fmt.Errorf("invalid constraint %q: %w", c.raw, err)
+			}
+			set = append(set, cmps...)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+func parseTerm(term string) ([]comparator, error) {
+	m := opRe.FindStringSubmatch(term)
+	if m == nil {
+		return nil, fmt.Errorf("bad term %q", term)
+	}
+	op, body := m[1], m[2]
+	if body == "*" || body == "x" || body == "X" {
+		if op != "" && op != "=" {
+			return nil, fmt.Errorf("bad term %q", term)
+		}
+		return nil, nil
+	}
+
+	// Wildcards (1.4.x, 2.*) turn into a prefix match on the given components.
+	parts := strings.Split(body, ".")
+	wildcard := false
+	for i, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			parts = parts[:i]
+			wildcard = true
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	v, ok := Parse(strings.Join(parts, "."))
+	if !ok {
+		return nil, fmt.Errorf("bad version in %q", term)
+	}
+	n := len(v.Release)
+
+	if wildcard || (op == "" && !v.IsPrerelease() && n < 3) {
+		op = "~"
+	}
+	switch op {
+	case "^":
+		// Bump the first non-zero component (or the last given one).
+		idx := 0
+		for idx < n-1 && v.Release[idx] == 0 {
+			idx++
+		}
+		return []comparator{{">=", v}, {"<", bump(v, idx)}}, nil
+	case "~":
+		idx := 1
+		if n < 2 {
+			idx = 0
+		}
+		return []comparator{{">=", v}, {"<", bump(v, idx)}}, nil
+	case "", "=":
+		return []comparator{{"=", v}}, nil
+	case "<":
+		if !v.IsPrerelease() {
+			// "<3" should not admit 3.0.0-rc.1.
+			v = Version{Release: v.Release, Pre: []string{"0"}}
+		}
+		return []comparator{{"<", v}}, nil
+	default:
+		return []comparator{{op, v}}, nil
+	}
+}
+
+// bump returns the lowest prerelease of v with component idx incremented and
+// everything after it dropped, e.g. bump(1.4.2, 1) = 1.5.0-0.
+func bump(v Version, idx int) Version {
+	rel := make([]int, idx+1)
+	copy(rel, v.Release)
+	rel[idx]++
+	return Version{Release: rel, Pre: []string{"0"}}
+}
+
+// String returns the constraint as written in config.
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// IsZero reports whether c is empty (matches everything).
+func (c Constraint) IsZero() bool {
+	return c.raw == ""
+}
+
+// Check reports whether v satisfies c. Prereleases only match when a term of
+// the matching alternative names a prerelease itself.
+func (c Constraint) Check(v Version) bool {
+	if c.IsZero() {
+		return true
+	}
+	for _, set := range c.sets {
+		if matchSet(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSet(set []comparator, v Version) bool {
+	allowPre := !v.IsPrerelease()
+	for _, cmp := range set {
+		c := Compare(v, cmp.v)
+		ok := false
+		switch cmp.op {
+		case "=":
+			ok = c == 0
+		case ">":
+			ok = c > 0
+		case ">=":
+			ok = c >= 0
+		case "<":
+			ok = c < 0
+		case "<=":
+			ok = c <= 0
+		}
+		if !ok {
+			return false
+		}
+		if cmp.v.IsPrerelease() && !isBumped(cmp.v) {
+			allowPre = true
+		}
+	}
+	return allowPre
+}
+
+func isBumped(v Version) bool {
+	return len(v.Pre) == 1 && v.Pre[0] == "0" && v.Raw == ""
+}
@@ -0,0 +1,112 @@
+package version
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CompareDebian compares two Debian package versions
+// ([epoch:]upstream[-revision]) the way dpkg does: the epoch first, then the
+// upstream version, then the revision. "~" sorts before anything, even the
+// end of the string, so 1.0~rc1 < 1.0. rpm versions (epoch:version-release)
+// compare the same way for everything but corner cases.
+func CompareDebian(a, b string) int {
+	ea, ua, ra := splitDebian(a)
+	eb, ub, rb := splitDebian(b)
+	if ea != eb {
+		return cmpInt(ea, eb)
+	}
+	if c := verrevcmp(ua, ub); c != 0 {
+		return c
+	}
+	return verrevcmp(ra, rb)
+}
+
+var repackRe = regexp.MustCompile(`[+~.](dfsg|ds|repack)[0-9.]*$`)
+
+// DebianUpstream returns the upstream part of a Debian or rpm package
+// version: without epoch, revision and repack suffixes (+dfsg, +ds, +repack).
+func DebianUpstream(s string) string {
+	_, upstream, _ := splitDebian(s)
+	return repackRe.ReplaceAllString(upstream, "")
+}
+
+func splitDebian(s string) (epoch int, upstream, revision string) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		if n, err := strconv.Atoi(s[:i]); err == nil {
+			epoch = n
+			s = s[i+1:]
+		}
+	}
+	if i := strings.LastIndexByte(s, '-'); i >= 0 {
+		return epoch, s[:i], s[i+1:]
+	}
+	return epoch, s, ""
+}
+
+// verrevcmp is dpkg's comparison of an upstream version or revision:
+// alternating non-digit runs (compared by debianOrder) and digit runs
+// (compared numerically).
+func verrevcmp(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			ac, bc := debianOrder(a, i), debianOrder(b, j)
+			if ac != bc {
+				return cmpInt(ac, bc)
+			}
+			i++
+			j++
+		}
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return cmpInt(firstDiff, 0)
+		}
+	}
+	return 0
+}
+
+// debianOrder ranks the character at s[i]: "~" first, then the end of the
+// string (and digits, which end a non-digit run), then letters, then
+// everything else.
+func debianOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	c := s[i]
+	switch {
+	case isDigit(c):
+		return 0
+	case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		return int(c)
+	case c == '~':
+		return -1
+	default:
+		return int(c) + 256
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,251 @@
+package version
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a tolerant semver-like version: any number of numeric release
+// components (1.2, 1.2.3, 1.2.3.4, 2026.1.24), an optional PEP 440
+// post-release number, optional prerelease identifiers and optional build
+// metadata.
+type Version struct {
+	Raw     string
+	Release []int
+	Post    string // 2.0.post1 -> "1"; sorts after the plain release
+	Pre     []string
+	Build   string
+}
+
+var (
+	releaseRe  = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*`)
+	isoDateRe  = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}`)
+	identSplit = regexp.MustCompile(`[0-9]+|[A-Za-z]+`)
+)
+
+// Parse parses s as a version. It accepts a leading "v", semver prerelease and
+// build suffixes, prerelease suffixes without a separator (1.0rc1), four-part
+// versions and calver (2026.1.24, 2024-01-15).
+func Parse(s string) (Version, bool) {
+	raw := strings.TrimSpace(s)
+	s = raw
+	if len(s) > 1 && (s[0] == 'v' || s[0] == 'V') && s[1] >= '0' && s[1] <= '9' {
+		s = s[1:]
+	}
+	if m := isoDateRe.FindString(s); m != "" {
+		s = strings.ReplaceAll(m, "-", ".") + s[len(m):]
+	}
+
+	rel := releaseRe.FindString(s)
+	if rel == "" {
+		return Version{}, false
+	}
+	v := Version{Raw: raw}
+	for _, p := range strings.Split(rel, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return Version{}, false
+		}
+		v.Release = append(v.Release, n)
+	}
+
+	rest := s[len(rel):]
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		v.Build = rest[i+1:]
+		rest = rest[:i]
+	}
+	if rest == "" {
+		return v, true
+	}
+	// Without a separator (1.0rc1) require a dotted release, so commit SHAs
+	// that happen to start with digits are not mistaken for versions.
+	if !strings.ContainsAny(rest[:1], ".-_") && !strings.Contains(rel, ".") {
+		return Version{}, false
+	}
+	rest = strings.TrimLeft(rest, ".-_")
+	if rest == "" {
+		return v, true
+	}
+	for _, part := range strings.FieldsFunc(rest, func(r rune) bool { return r == '.' || r == '-' || r == '_' }) {
+		idents := identSplit.FindAllString(part, -1)
+		if strings.Join(idents, "") != part {
+			return Version{}, false
+		}
+		v.Pre = append(v.Pre, idents...)
+	}
+	if len(v.Pre) > 0 && strings.EqualFold(v.Pre[0], "post") {
+		// PEP 440 post-release (2.0.post1, 2.0post1, 2.0.post); anything
+		// after it (2.0.post1.dev1) stays a prerelease of the post-release.
+		v.Post, v.Pre = "0", v.Pre[1:]
+		if len(v.Pre) > 0 && isNumeric(v.Pre[0]) {
+			v.Post, v.Pre = v.Pre[0], v.Pre[1:]
+		}
+	}
+	return v, true
+}
+
+// IsPrerelease reports whether v carries prerelease identifiers.
+func (v Version) IsPrerelease() bool {
+	return len(v.Pre) > 0
+}
+
+// Compare returns -1, 0 or 1 when a is older than, equal to or newer than b.
+// Build metadata is ignored.
+func Compare(a, b Version) int {
+	n := len(a.Release)
+	if len(b.Release) > n {
+		n = len(b.Release)
+	}
+	for i := 0; i < n; i++ {
+		x, y := component(a.Release, i), component(b.Release, i)
+		if x != y {
+			return cmpInt(x, y)
+		}
+	}
+	if c := comparePost(a.Post, b.Post); c != 0 {
+		return c
+	}
+
+	switch {
+	case len(a.Pre) == 0 && len(b.Pre) == 0:
+		return 0
+	case len(a.Pre) == 0:
+		return 1
+	case len(b.Pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(a.Pre) && i < len(b.Pre); i++ {
+		if c := compareIdent(a.Pre[i], b.Pre[i]); c != 0 {
+			return c
+		}
+	}
+	return cmpInt(len(a.Pre), len(b.Pre))
+}
+
+// CompareStrings parses both strings and compares them. ok is false when
+// either side is not a version.
+func CompareStrings(a, b string) (c int, ok bool) {
+	va, ok := Parse(a)
+	if !ok {
+		return 0, false
+	}
+	vb, ok := Parse(b)
+	if !ok {
+		return 0, false
+	}
+	return Compare(va, vb), true
+}
+
+func component(r []int, i int) int {
+	if i < len(r) {
+		return r[i]
+	}
+	return 0
+}
+
+func comparePost(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	}
+	x, _ := strconv.Atoi(a)
+	y, _ := strconv.Atoi(b)
+	return cmpInt(x, y)
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func compareIdent(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return cmpInt(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+const (
+	KindMajor      = "major"
+	KindMinor      = "minor"
+	KindPatch      = "patch"
+	KindPrerelease = "prerelease"
+)
+
+var kindRank = map[string]int{
+	KindPrerelease: 1,
+	KindPatch:      2,
+	KindMinor:      3,
+	KindMajor:      4,
+}
+
+// Kind classifies the update from -> to as major|minor|patch|prerelease.
+// It returns "" when to is not newer than from.
+func Kind(from, to Version) string {
+	if Compare(from, to) >= 0 {
+		return ""
+	}
+	for i := 0; i < len(from.Release) || i < len(to.Release); i++ {
+		if component(from.Release, i) == component(to.Release, i) {
+			continue
+		}
+		switch i {
+		case 0:
+			return KindMajor
+		case 1:
+			return KindMinor
+		default:
+			return KindPatch
+		}
+	}
+	if from.Post != to.Post {
+		return KindPatch
+	}
+	return KindPrerelease
+}
+
+// KindStrings parses both strings and classifies the update between them.
+func KindStrings(from, to string) string {
+	vf, ok := Parse(from)
+	if !ok {
+		return ""
+	}
+	vt, ok := Parse(to)
+	if !ok {
+		return ""
+	}
+	return Kind(vf, vt)
+}
+
+// ValidKind reports whether kind is one of major|minor|patch|prerelease.
+func ValidKind(kind string) bool {
+	_, ok := kindRank[kind]
+	return ok
+}
+
+// AtLeast reports whether kind is as severe as min (major > minor > patch > prerelease).
+func AtLeast(kind, min string) bool {
+	return kindRank[kind] >= kindRank[min]
+}
@@ -0,0 +1,165 @@
+package version
+
+import "testing"
+
+func TestCompareStrings(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.10.0", "1.9.9", 1},
+		{"2.1.0-beta", "2.0.9", 1},
+		{"2.0.0-rc.1", "2.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-rc.1", "1.0.0-beta.11", 1},
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+		{"1.0rc1", "1.0", -1},
+		{"1.0rc2", "1.0rc10", -1},
+		{"1.2.3.4", "1.2.3.10", -1},
+		{"2026.1.24-3", "2026.2.2", -1},
+		{"2024-01-15", "2024.2.1", -1},
+		{"2.0.post1", "2.0", 1},
+		{"2.0.post1", "2.0.post2", -1},
+		{"2.0.post1", "2.0.1", -1},
+		{"2.0.post1.dev1", "2.0.post1", -1},
+		{"2.0rc1", "2.0.post1", -1},
+	}
+	for _, tc := range cases {
+		got, ok := CompareStrings(tc.a, tc.b)
+		if !ok {
+			t.Fatalf("%s vs %s: not parsed", tc.a, tc.b)
+		}
+		if got != tc.want {
+			t.Fatalf("%s vs %s: got %d want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestParseRejectsNonVersions(t *testing.T) {
+	for _, s := range []string{"", "latest", "unknown", "1006798459a17e11903137ce09198e64686a4dbb", "sha256:abc"} {
+		if _, ok := Parse(s); ok {
+			t.Fatalf("%q parsed as version", s)
+		}
+	}
+}
+
+func TestKindStrings(t *testing.T) {
+	cases := []struct {
+		from, to string
+		want     string
+	}{
+		{"1.2.3", "2.0.0", KindMajor},
+		{"1.2.3", "1.3.0", KindMinor},
+		{"1.2.3", "1.2.4", KindPatch},
+		{"1.2.3.1", "1.2.3.2", KindPatch},
+		{"2.0.0-rc.1", "2.0.0", KindPrerelease},
+		{"2.0", "2.0.post1", KindPatch},
+		{"1.2.3", "1.2.3", ""},
+		{"1.3.0", "1.2.3", ""},
+		{"abc", "1.2.3", ""},
+	}
+	for _, tc := range cases {
+		if got := KindStrings(tc.from, tc.to); got != tc.want {
+			t.Fatalf("%s -> %s: got %q want %q", tc.from, tc.to, got, tc.want)
+		}
+	}
+	if !AtLeast(KindMajor, KindMinor) || AtLeast(KindPatch, KindMinor) {
+		t.Fatalf("unexpected severity ordering")
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	cases := []struct {
+		constraint string
+		v          string
+		want       bool
+	}{
+		{"^2.0", "2.9.1", true},
+		{"^2.0", "3.0.0", false},
+		{"^2.0", "1.9.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"~1.4", "1.4.7", true},
+		{"~1.4", "1.5.0", false},
+		{"~1.4.2", "1.4.1", false},
+		{"<3", "2.99.0", true},
+		{"<3", "3.0.0-rc.1", false},
+		{"<3", "3.0.0", false},
+		{">=1.2, <2", "1.5.0", true},
+		{">= 1.2 < 2", "2.0.0", false},
+		{"20", "20.11.1", true},
+		{"20", "21.0.0", false},
+		{"1.4.x", "1.4.3", true},
+		{"=1.2.3", "v1.2.3", true},
+		{"^1 || ^3", "3.1.0", true},
+		{"^1 || ^3", "2.1.0", false},
+		{"^2.0", "2.1.0-beta.1", false},
+		{">=2.1.0-beta.1", "2.1.0-beta.2", true},
+		{"", "0.0.1", true},
+	}
+	for _, tc := range cases {
+		c, err := ParseConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.constraint, err)
+		}
+		v, ok := Parse(tc.v)
+		if !ok {
+			t.Fatalf("parse %q", tc.v)
+		}
+		if got := c.Check(v); got != tc.want {
+			t.Fatalf("%q check %s: got %t want %t", tc.constraint, tc.v, got, tc.want)
+		}
+	}
+
+	for _, bad := range []string{">=", "^abc", "1.2 ||"} {
+		if _, err := ParseConstraint(bad); err == nil {
+			t.Fatalf("%q: expected error", bad)
+		}
+	}
+}
+
+func TestCompareDebian(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3-1", "1.2.3-1", 0},
+		{"1.2.3-1", "1.2.3-2", -1},
+		{"1.2.10", "1.2.9", 1},
+		{"1:1.0-1", "2.0-1", 1},
+		{"1.0~rc1-1", "1.0-1", -1},
+		{"1.0~~", "1.0~", -1},
+		{"1.0a", "1.0", 1},
+		{"1.0+dfsg-1", "1.0-1", 1},
+		{"2.39.2-1ubuntu1", "2.39.2-1", 1},
+		{"1.01", "1.1", 0},
+	}
+	for _, tc := range cases {
+		if got := CompareDebian(tc.a, tc.b); got != tc.want {
+			t.Fatalf("CompareDebian(%q, %q) = %d want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestDebianUpstream(t *testing.T) {
+	cases := map[string]string{
+		"1:2.39.2-1ubuntu1":     "2.39.2",
+		"7.0.1+dfsg-2":          "7.0.1",
+		"1.24.0~rc1+ds1-1":      "1.24.0~rc1",
+		"3.0.13-0ubuntu3.4":     "3.0.13",
+		"0:5.14.0-427.el9":      "5.14.0",
+		"1.2.3":                 "1.2.3",
+		"2:8.2.3995-1ubuntu2.1": "8.2.3995",
+	}
+	for in, want := range cases {
+		if got := DebianUpstream(in); got != want {
+			t.Fatalf("DebianUpstream(%q) = %q want %q", in, got, want)
+		}
+	}
+}